@@ -1,6 +1,8 @@
 package did
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding"
 	"encoding/json"
 	"errors"
@@ -12,6 +14,11 @@ import (
 
 var _ fmt.Stringer = DID{}
 var _ encoding.TextMarshaler = DID{}
+var _ encoding.TextUnmarshaler = &DID{}
+var _ encoding.BinaryMarshaler = DID{}
+var _ encoding.BinaryUnmarshaler = &DID{}
+var _ sql.Scanner = &DID{}
+var _ driver.Valuer = DID{}
 
 // DIDContextV1 contains the JSON-LD context for a DID Document
 const DIDContextV1 = "https://www.w3.org/ns/did/v1"
@@ -54,8 +61,61 @@ func (d DID) MarshalText() ([]byte, error) {
 	return []byte(d.String()), nil
 }
 
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed using ParseDID, so it accepts the same input as UnmarshalJSON.
+func (d *DID) UnmarshalText(text []byte) error {
+	parsed, err := ParseDID(string(text))
+	if err != nil {
+		return err
+	}
+	*d = *parsed
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The binary form is the same as the text form.
+func (d DID) MarshalBinary() ([]byte, error) {
+	return d.MarshalText()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The binary form is the same as the text form.
+func (d *DID) UnmarshalBinary(data []byte) error {
+	return d.UnmarshalText(data)
+}
+
+// Scan implements sql.Scanner. It accepts a string or []byte which is parsed as UnmarshalText does; NULL yields an empty DID.
+func (d *DID) Scan(src any) error {
+	if src == nil {
+		*d = DID{}
+		return nil
+	}
+	text, err := scanText(src, "DID")
+	if err != nil {
+		return err
+	}
+	return d.UnmarshalText(text)
+}
+
+// Value implements driver.Valuer. It returns the DID as string, or NULL if the DID is empty.
+func (d DID) Value() (driver.Value, error) {
+	if d.Empty() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+// scanText converts a value read from a database to text, for use in sql.Scanner implementations.
+func scanText(src any, typeName string) ([]byte, error) {
+	switch value := src.(type) {
+	case string:
+		return []byte(value), nil
+	case []byte:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unsupported type for %s: %T", typeName, src)
+	}
+}
+
 // Equals checks whether the DID equals to another DID.
-// The check is case-sensitive.
+// The check is case-sensitive. Use EqualsNormalized to compare equivalent DIDs that differ in notation.
 func (d DID) Equals(other DID) bool {
 	return d.String() == other.String()
 }
@@ -92,6 +152,12 @@ func (d DID) URI() ssi.URI {
 	}
 }
 
+// ResolveReference resolves a (relative) reference against the DID as base,
+// according to RFC 3986 section 5.2. See DIDURL.ResolveReference for details.
+func (d DID) ResolveReference(ref string) (*DIDURL, error) {
+	return DIDURL{DID: d}.ResolveReference(ref)
+}
+
 // ParseDID parses a raw DID.
 // If the input contains a path, query or fragment, use the ParseDIDURL instead.
 // If it can't be parsed, an error is returned.
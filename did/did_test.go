@@ -75,6 +75,101 @@ func TestDID_MarshalText(t *testing.T) {
 	assert.Equal(t, []byte(expected), actual)
 }
 
+func TestDID_UnmarshalText(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var id DID
+		err := id.UnmarshalText([]byte("did:example:123"))
+		require.NoError(t, err)
+		assert.Equal(t, MustParseDID("did:example:123"), id)
+	})
+	t.Run("empty", func(t *testing.T) {
+		var id DID
+		err := id.UnmarshalText([]byte{})
+		assert.ErrorIs(t, err, ErrInvalidDID)
+	})
+	t.Run("accepts the same input as UnmarshalJSON", func(t *testing.T) {
+		for _, input := range []string{"did:example:123", "", "did:example:123#fragment", "example"} {
+			var fromText, fromJSON DID
+			textErr := fromText.UnmarshalText([]byte(input))
+			jsonErr := json.Unmarshal([]byte(`"`+input+`"`), &fromJSON)
+			assert.Equal(t, jsonErr == nil, textErr == nil, input)
+			assert.Equal(t, fromJSON, fromText, input)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var id DID
+		err := id.UnmarshalText([]byte("did:example:123#fragment"))
+		assert.ErrorIs(t, err, ErrInvalidDID)
+	})
+	t.Run("as JSON map key", func(t *testing.T) {
+		var actual map[DID]string
+		err := json.Unmarshal([]byte(`{"did:example:123": "value"}`), &actual)
+		require.NoError(t, err)
+		assert.Equal(t, map[DID]string{MustParseDID("did:example:123"): "value"}, actual)
+
+		data, err := json.Marshal(actual)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"did:example:123": "value"}`, string(data))
+	})
+	t.Run("empty JSON map key", func(t *testing.T) {
+		var actual map[DID]string
+		err := json.Unmarshal([]byte(`{"": "value"}`), &actual)
+		assert.ErrorIs(t, err, ErrInvalidDID)
+	})
+}
+
+func TestDID_MarshalBinary(t *testing.T) {
+	expected := MustParseDID("did:web:example.com%3A3000:user:alice")
+	data, err := expected.MarshalBinary()
+	require.NoError(t, err)
+
+	var actual DID
+	err = actual.UnmarshalBinary(data)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestDID_Scan(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		var id DID
+		require.NoError(t, id.Scan("did:example:123"))
+		assert.Equal(t, MustParseDID("did:example:123"), id)
+	})
+	t.Run("bytes", func(t *testing.T) {
+		var id DID
+		require.NoError(t, id.Scan([]byte("did:example:123")))
+		assert.Equal(t, MustParseDID("did:example:123"), id)
+	})
+	t.Run("NULL", func(t *testing.T) {
+		id := MustParseDID("did:example:123")
+		require.NoError(t, id.Scan(nil))
+		assert.True(t, id.Empty())
+	})
+	t.Run("invalid DID", func(t *testing.T) {
+		var id DID
+		assert.ErrorIs(t, id.Scan("not a DID"), ErrInvalidDID)
+	})
+	t.Run("empty string", func(t *testing.T) {
+		var id DID
+		assert.ErrorIs(t, id.Scan(""), ErrInvalidDID)
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		var id DID
+		assert.EqualError(t, id.Scan(123), "unsupported type for DID: int")
+	})
+}
+
+func TestDID_Value(t *testing.T) {
+	value, err := MustParseDID("did:example:123").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "did:example:123", value)
+
+	value, err = DID{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
 func TestDID_Equal(t *testing.T) {
 	const did = "did:example:123"
 	t.Run("equal", func(t *testing.T) {
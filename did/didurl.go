@@ -1,19 +1,24 @@
 package did
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/nuts-foundation/go-did"
 	"net/url"
-	"regexp"
 	"strings"
 )
 
 var _ fmt.Stringer = DIDURL{}
 var _ encoding.TextMarshaler = DIDURL{}
-
-var didURLPattern = regexp.MustCompile(`^(did:([a-z0-9]+):((?:(?:[a-zA-Z0-9.\-_:])+|(?:%[0-9a-fA-F]{2})+)+)|)(/.*?|)(\?.*?|)(#.*|)$`)
+var _ encoding.TextUnmarshaler = &DIDURL{}
+var _ encoding.BinaryMarshaler = DIDURL{}
+var _ encoding.BinaryUnmarshaler = &DIDURL{}
+var _ sql.Scanner = &DIDURL{}
+var _ driver.Valuer = DIDURL{}
 
 type DIDURL struct {
 	DID
@@ -34,7 +39,7 @@ type DIDURL struct {
 }
 
 // Equals checks whether the DIDURL equals to another DIDURL.
-// The check is case-sensitive.
+// The check is case-sensitive. Use EqualsNormalized to compare equivalent DID URLs that differ in notation.
 func (d DIDURL) Equals(other DIDURL) bool {
 	return d.cleanup().String() == other.cleanup().String()
 }
@@ -60,6 +65,52 @@ func (d DIDURL) MarshalJSON() ([]byte, error) {
 	return json.Marshal(d.String())
 }
 
+// MarshalText implements encoding.TextMarshaler
+func (d DIDURL) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed using ParseDIDURL.
+func (d *DIDURL) UnmarshalText(text []byte) error {
+	parsed, err := ParseDIDURL(string(text))
+	if err != nil {
+		return err
+	}
+	*d = *parsed
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The binary form is the same as the text form.
+func (d DIDURL) MarshalBinary() ([]byte, error) {
+	return d.MarshalText()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The binary form is the same as the text form.
+func (d *DIDURL) UnmarshalBinary(data []byte) error {
+	return d.UnmarshalText(data)
+}
+
+// Scan implements sql.Scanner. It accepts a string or []byte which is parsed as UnmarshalText does; NULL yields an empty DID URL.
+func (d *DIDURL) Scan(src any) error {
+	if src == nil {
+		*d = DIDURL{}
+		return nil
+	}
+	text, err := scanText(src, "DID URL")
+	if err != nil {
+		return err
+	}
+	return d.UnmarshalText(text)
+}
+
+// Value implements driver.Valuer. It returns the DID URL as string, or NULL if the DID URL is empty.
+func (d DIDURL) Value() (driver.Value, error) {
+	if d.Empty() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
 // Empty checks whether the DID is set or not
 func (d DIDURL) Empty() bool {
 	return d.DID.Empty() && d.urlEmpty()
@@ -112,49 +163,115 @@ func (d DIDURL) URI() ssi.URI {
 	return result
 }
 
-// ParseDIDURL parses a DID URL.
-// https://www.w3.org/TR/did-core/#did-url-syntax
-// A DID URL is a URL that builds on the DID scheme.
-func ParseDIDURL(input string) (*DIDURL, error) {
-	// There are 6 submatches (base 0)
-	// 0. DID + path + query + fragment
-	// 1. DID
-	// 2. method
-	// 3. id
-	// 4. path (starting with '/')
-	// 5. query (starting with '?')
-	// 6. fragment (starting with '#')
-	matches := didURLPattern.FindStringSubmatch(input)
-	if len(matches) == 0 {
-		return nil, ErrInvalidDID
-	}
-
-	result := DIDURL{
-		DID: DID{
-			Method: matches[2],
-			ID:     matches[3],
-		},
-		Path:     strings.TrimPrefix(matches[4], "/"),
-		Fragment: strings.TrimPrefix(matches[6], "#"),
-	}
-	var err error
-	result.DecodedID, err = url.PathUnescape(result.ID)
-	if err != nil {
-		return nil, ErrInvalidDID.wrap(fmt.Errorf("invalid ID: %w", err))
+// ResolveReference resolves a (relative) reference against this DID URL as base,
+// according to the algorithm specified by RFC 3986, section 5.2 (https://www.rfc-editor.org/rfc/rfc3986#section-5.2).
+// The DID of the base acts as authority, meaning relative references can't navigate outside the DID:
+// an absolute path (e.g. "/path") or dot segments (e.g. "../path") are resolved relative to the DID.
+// Supported references are DID URLs (which are returned with dot segments removed from their path),
+// fragments (e.g. "#key-1"), queries (e.g. "?service=foo"), absolute paths (e.g. "/path") and relative paths (e.g. "../path").
+// It returns an error if the base is not an absolute DID URL, or if the reference is a URI with a scheme other than "did".
+func (d DIDURL) ResolveReference(ref string) (*DIDURL, error) {
+	if d.DID.Empty() {
+		return nil, ErrInvalidDID.wrap(errors.New("base must be an absolute DID URL"))
 	}
-	result.DecodedPath, err = url.PathUnescape(result.Path)
-	if err != nil {
-		return nil, ErrInvalidDID.wrap(fmt.Errorf("invalid path: %w", err))
+	if strings.HasPrefix(ref, "did:") {
+		result, err := ParseDIDURL(ref)
+		if err != nil {
+			return nil, err
+		}
+		if result.Path == "" {
+			return result, nil
+		}
+		return ParseDIDURL(result.DID.String() + removeDotSegments("/"+result.Path) + strings.TrimPrefix(ref, result.DID.String()+"/"+result.Path))
 	}
-	result.DecodedFragment, err = url.PathUnescape(result.Fragment)
-	if err != nil {
-		return nil, ErrInvalidDID.wrap(fmt.Errorf("invalid fragment: %w", err))
+	refPath, refQuery, refFragment, hasQuery, hasFragment := splitReference(ref)
+	if strings.HasPrefix(refPath, "//") {
+		return nil, ErrInvalidDID.wrap(errors.New("reference can't contain an authority"))
+	}
+	if i := strings.IndexByte(refPath, ':'); i >= 0 && !strings.Contains(refPath[:i], "/") {
+		return nil, ErrInvalidDID.wrap(fmt.Errorf("reference is not a DID URL: %s", ref))
 	}
-	result.Query, err = url.ParseQuery(strings.TrimPrefix(matches[5], "?"))
+	var basePath string
+	if d.Path != "" {
+		basePath = "/" + d.Path
+	}
+	var targetPath string
+	switch {
+	case refPath == "":
+		targetPath = basePath
+		if !hasQuery && len(d.Query) > 0 {
+			refQuery = d.Query.Encode()
+			hasQuery = true
+		}
+	case strings.HasPrefix(refPath, "/"):
+		targetPath = removeDotSegments(refPath)
+	default:
+		// merge base path and reference path (RFC 3986 section 5.2.3)
+		if basePath == "" {
+			targetPath = removeDotSegments("/" + refPath)
+		} else {
+			targetPath = removeDotSegments(basePath[:strings.LastIndexByte(basePath, '/')+1] + refPath)
+		}
+	}
+	result := d.DID.String() + targetPath
+	if hasQuery {
+		result += "?" + refQuery
+	}
+	if hasFragment {
+		result += "#" + refFragment
+	}
+	return ParseDIDURL(result)
+}
+
+// splitReference splits a URI reference into its path, query and fragment components.
+func splitReference(ref string) (path string, query string, fragment string, hasQuery bool, hasFragment bool) {
+	path = ref
+	if i := strings.IndexByte(path, '#'); i >= 0 {
+		fragment = path[i+1:]
+		path = path[:i]
+		hasFragment = true
+	}
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		query = path[i+1:]
+		path = path[:i]
+		hasQuery = true
+	}
+	return
+}
+
+// removeDotSegments removes "." and ".." segments from a path, as specified by RFC 3986 section 5.2.4.
+func removeDotSegments(input string) string {
+	var output []string
+	segments := strings.Split(input, "/")
+	for i, segment := range segments {
+		switch segment {
+		case ".":
+			if i == len(segments)-1 {
+				output = append(output, "")
+			}
+		case "..":
+			if len(output) > 1 {
+				output = output[:len(output)-1]
+			}
+			if i == len(segments)-1 {
+				output = append(output, "")
+			}
+		default:
+			output = append(output, segment)
+		}
+	}
+	return strings.Join(output, "/")
+}
+
+// ParseDIDURL parses a DID URL.
+// https://www.w3.org/TR/did-core/#did-url-syntax
+// A DID URL is a URL that builds on the DID scheme. Relative DID URLs (e.g. "#key-1") are supported as well.
+// If the input can't be parsed, the returned error wraps ErrInvalidDID and a SyntaxError describing the problem.
+func ParseDIDURL(input string) (*DIDURL, error) {
+	result, err := parseDIDURL(input)
 	if err != nil {
-		return nil, ErrInvalidDID.wrap(err)
+		return nil, err
 	}
-	result = result.cleanup()
 	return &result, nil
 }
 
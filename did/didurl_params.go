@@ -0,0 +1,184 @@
+package did
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/multiformats/go-multibase"
+)
+
+// DID parameters as specified by DID Core (https://www.w3.org/TR/did-core/#did-parameters).
+const (
+	// ServiceParameter identifies a service from the DID document by service ID.
+	ServiceParameter = "service"
+	// RelativeRefParameter is a relative URI reference that identifies a resource at a service endpoint, used together with the service parameter.
+	RelativeRefParameter = "relativeRef"
+	// VersionIDParameter identifies a specific version of a DID document.
+	VersionIDParameter = "versionId"
+	// VersionTimeParameter identifies the version of a DID document that was valid at the given time.
+	VersionTimeParameter = "versionTime"
+	// HashLinkParameter is a hashlink (https://datatracker.ietf.org/doc/html/draft-sporny-hashlink) to add integrity protection to the DID URL.
+	HashLinkParameter = "hl"
+	// ResourceParameter indicates the DID URL dereferences to a resource rather than a DID document (e.g. used by did:cheqd).
+	ResourceParameter = "resource"
+)
+
+// versionTimeLayout is the format of versionTime values: an XML datetime normalized to UTC, without sub-second precision.
+const versionTimeLayout = "2006-01-02T15:04:05Z"
+
+// Service returns the value of the service parameter, or an empty string if it's not present.
+func (d DIDURL) Service() string {
+	return d.Query.Get(ServiceParameter)
+}
+
+// RelativeRef returns the value of the relativeRef parameter, or an empty string if it's not present.
+func (d DIDURL) RelativeRef() string {
+	return d.Query.Get(RelativeRefParameter)
+}
+
+// VersionID returns the value of the versionId parameter, or an empty string if it's not present.
+func (d DIDURL) VersionID() string {
+	return d.Query.Get(VersionIDParameter)
+}
+
+// VersionTime returns the value of the versionTime parameter, or nil if it's not present.
+// It returns an error if the value isn't an XML datetime normalized to UTC without sub-second precision (e.g. 2021-05-10T17:00:00Z), as required by DID Core.
+func (d DIDURL) VersionTime() (*time.Time, error) {
+	value := d.Query.Get(VersionTimeParameter)
+	if value == "" {
+		return nil, nil
+	}
+	result, err := time.Parse(versionTimeLayout, value)
+	if err != nil {
+		return nil, ErrInvalidDID.wrap(fmt.Errorf("invalid %s parameter: %w", VersionTimeParameter, err))
+	}
+	// time.Parse accepts fractional seconds even if the layout doesn't specify them
+	if result.Format(versionTimeLayout) != value {
+		return nil, ErrInvalidDID.wrap(fmt.Errorf("invalid %s parameter: must be normalized to UTC without sub-second precision: %s", VersionTimeParameter, value))
+	}
+	return &result, nil
+}
+
+// HashLink returns the value of the hl parameter, or an empty string if it's not present.
+func (d DIDURL) HashLink() string {
+	return d.Query.Get(HashLinkParameter)
+}
+
+// Resource returns whether the resource parameter is set to true.
+func (d DIDURL) Resource() bool {
+	value, _ := strconv.ParseBool(d.Query.Get(ResourceParameter))
+	return value
+}
+
+// DIDURLBuilder builds DID URLs from a DID, path, fragment and DID parameters.
+// Values are given in unescaped form and escaped when the DID URL is formatted, so the values can be read back after parsing it.
+// Invalid values are reported by Build, which returns the first error encountered.
+type DIDURLBuilder struct {
+	result DIDURL
+	err    error
+}
+
+// NewDIDURLBuilder creates a DIDURLBuilder for DID URLs of the given DID.
+func NewDIDURLBuilder(id DID) *DIDURLBuilder {
+	return &DIDURLBuilder{result: DIDURL{DID: id}}
+}
+
+// Path sets the path (without leading '/').
+func (b *DIDURLBuilder) Path(path string) *DIDURLBuilder {
+	b.result.DecodedPath = path
+	b.result.Path = (&url.URL{Path: path}).EscapedPath()
+	return b
+}
+
+// Fragment sets the fragment (without leading '#').
+func (b *DIDURLBuilder) Fragment(fragment string) *DIDURLBuilder {
+	b.result.DecodedFragment = fragment
+	b.result.Fragment = (&url.URL{Fragment: fragment}).EscapedFragment()
+	return b
+}
+
+// Service sets the service parameter.
+func (b *DIDURLBuilder) Service(service string) *DIDURLBuilder {
+	if service == "" {
+		b.fail(ServiceParameter, errors.New("must not be empty"))
+	}
+	return b.Parameter(ServiceParameter, service)
+}
+
+// RelativeRef sets the relativeRef parameter, which must be a relative URI reference (e.g. "/path?query").
+func (b *DIDURLBuilder) RelativeRef(ref string) *DIDURLBuilder {
+	if parsed, err := url.Parse(ref); err != nil {
+		b.fail(RelativeRefParameter, err)
+	} else if parsed.IsAbs() || parsed.Host != "" {
+		b.fail(RelativeRefParameter, errors.New("must be a relative URI reference"))
+	}
+	return b.Parameter(RelativeRefParameter, ref)
+}
+
+// VersionID sets the versionId parameter.
+func (b *DIDURLBuilder) VersionID(versionID string) *DIDURLBuilder {
+	if versionID == "" {
+		b.fail(VersionIDParameter, errors.New("must not be empty"))
+	}
+	return b.Parameter(VersionIDParameter, versionID)
+}
+
+// VersionTime sets the versionTime parameter. As required by DID Core, the time is normalized to UTC and truncated to seconds.
+func (b *DIDURLBuilder) VersionTime(versionTime time.Time) *DIDURLBuilder {
+	return b.Parameter(VersionTimeParameter, versionTime.UTC().Format(versionTimeLayout))
+}
+
+// HashLink sets the hl parameter, which must be a multibase encoded hash.
+func (b *DIDURLBuilder) HashLink(hashLink string) *DIDURLBuilder {
+	if _, _, err := multibase.Decode(hashLink); err != nil {
+		b.fail(HashLinkParameter, err)
+	}
+	return b.Parameter(HashLinkParameter, hashLink)
+}
+
+// Resource sets the resource parameter.
+func (b *DIDURLBuilder) Resource(resource bool) *DIDURLBuilder {
+	return b.Parameter(ResourceParameter, strconv.FormatBool(resource))
+}
+
+// Parameter sets a (method-specific) parameter, replacing existing values.
+func (b *DIDURLBuilder) Parameter(name string, value string) *DIDURLBuilder {
+	if b.result.Query == nil {
+		b.result.Query = url.Values{}
+	}
+	b.result.Query.Set(name, value)
+	return b
+}
+
+// Build returns the DID URL, or the first error encountered when setting its values.
+func (b *DIDURLBuilder) Build() (*DIDURL, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.result.DID.Empty() {
+		return nil, ErrInvalidDID.wrap(errors.New("DID URL must have a DID"))
+	}
+	result := b.result
+	result.Query = cloneValues(b.result.Query)
+	return &result, nil
+}
+
+func (b *DIDURLBuilder) fail(parameter string, err error) {
+	if b.err == nil {
+		b.err = ErrInvalidDID.wrap(fmt.Errorf("invalid %s parameter: %w", parameter, err))
+	}
+}
+
+func cloneValues(values url.Values) url.Values {
+	if values == nil {
+		return nil
+	}
+	result := make(url.Values, len(values))
+	for key, value := range values {
+		result[key] = append([]string(nil), value...)
+	}
+	return result
+}
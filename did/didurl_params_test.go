@@ -0,0 +1,115 @@
+package did
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDIDURL_Parameters(t *testing.T) {
+	t.Run("present", func(t *testing.T) {
+		input := MustParseDIDURL("did:example:123?service=files&relativeRef=%2Fdocs%3Fpage%3D1&versionId=2&versionTime=2021-05-10T17:00:00Z&hl=zQmWvQxTqbG2Z9HPJgG57jjwR154cKhbtJenbyYTWkjgF3e&resource=true")
+
+		assert.Equal(t, "files", input.Service())
+		assert.Equal(t, "/docs?page=1", input.RelativeRef())
+		assert.Equal(t, "2", input.VersionID())
+		assert.Equal(t, "zQmWvQxTqbG2Z9HPJgG57jjwR154cKhbtJenbyYTWkjgF3e", input.HashLink())
+		assert.True(t, input.Resource())
+		versionTime, err := input.VersionTime()
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 10, 17, 0, 0, 0, time.UTC), *versionTime)
+	})
+	t.Run("absent", func(t *testing.T) {
+		input := MustParseDIDURL("did:example:123")
+
+		assert.Empty(t, input.Service())
+		assert.Empty(t, input.RelativeRef())
+		assert.Empty(t, input.VersionID())
+		assert.Empty(t, input.HashLink())
+		assert.False(t, input.Resource())
+		versionTime, err := input.VersionTime()
+		assert.NoError(t, err)
+		assert.Nil(t, versionTime)
+	})
+	t.Run("invalid versionTime", func(t *testing.T) {
+		_, err := MustParseDIDURL("did:example:123?versionTime=yesterday").VersionTime()
+
+		assert.ErrorIs(t, err, ErrInvalidDID)
+		assert.ErrorContains(t, err, "invalid versionTime parameter")
+	})
+	t.Run("versionTime not normalized", func(t *testing.T) {
+		for _, value := range []string{"2021-05-10T19:00:00%2B02:00", "2021-05-10T17:00:00.5Z", "2021-05-10T17:00:00.000Z", "2021-05-10t17:00:00z"} {
+			t.Run(value, func(t *testing.T) {
+				_, err := MustParseDIDURL("did:example:123?versionTime=" + value).VersionTime()
+
+				assert.ErrorIs(t, err, ErrInvalidDID)
+				assert.ErrorContains(t, err, "invalid versionTime parameter")
+			})
+		}
+	})
+}
+
+func TestDIDURLBuilder(t *testing.T) {
+	id := MustParseDID("did:example:123")
+	t.Run("roundtrip", func(t *testing.T) {
+		versionTime := time.Date(2021, 5, 10, 19, 0, 0, 500, time.FixedZone("CEST", 2*60*60))
+
+		actual, err := NewDIDURLBuilder(id).
+			Path("some path/file").
+			Fragment("key 1").
+			Service("files & more").
+			RelativeRef("/docs?page=1#top").
+			VersionID("2").
+			VersionTime(versionTime).
+			HashLink("zQmWvQxTqbG2Z9HPJgG57jjwR154cKhbtJenbyYTWkjgF3e").
+			Resource(true).
+			Parameter("custom", "a=b").
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:123/some%20path/file?custom=a%3Db&hl=zQmWvQxTqbG2Z9HPJgG57jjwR154cKhbtJenbyYTWkjgF3e&relativeRef=%2Fdocs%3Fpage%3D1%23top&resource=true&service=files+%26+more&versionId=2&versionTime=2021-05-10T17%3A00%3A00Z#key%201", actual.String())
+		parsed, err := ParseDIDURL(actual.String())
+		require.NoError(t, err)
+		assert.Equal(t, *actual, *parsed)
+		assert.Equal(t, "files & more", parsed.Service())
+		assert.Equal(t, "/docs?page=1#top", parsed.RelativeRef())
+		parsedTime, err := parsed.VersionTime()
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 10, 17, 0, 0, 0, time.UTC), *parsedTime)
+	})
+	t.Run("without parameters", func(t *testing.T) {
+		actual, err := NewDIDURLBuilder(id).Fragment("key-1").Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, MustParseDIDURL("did:example:123#key-1"), *actual)
+	})
+	t.Run("built DID URLs are independent", func(t *testing.T) {
+		builder := NewDIDURLBuilder(id).Service("a")
+		first, _ := builder.Build()
+		second, _ := builder.Service("b").Build()
+
+		assert.Equal(t, "a", first.Service())
+		assert.Equal(t, "b", second.Service())
+	})
+	t.Run("invalid values", func(t *testing.T) {
+		testCases := map[string]*DIDURLBuilder{
+			"invalid service parameter: must not be empty":                      NewDIDURLBuilder(id).Service(""),
+			"invalid versionId parameter: must not be empty":                    NewDIDURLBuilder(id).VersionID(""),
+			"invalid relativeRef parameter: must be a relative URI reference":   NewDIDURLBuilder(id).RelativeRef("https://example.com/docs"),
+			"invalid hl parameter: ":                                            NewDIDURLBuilder(id).HashLink("not-multibase"),
+			"invalid DID: DID URL must have a DID":                              NewDIDURLBuilder(DID{}).Fragment("key-1"),
+			"invalid service parameter: must not be empty (first error counts)": NewDIDURLBuilder(id).Service("").VersionID(""),
+		}
+		for expected, builder := range testCases {
+			t.Run(expected, func(t *testing.T) {
+				_, err := builder.Build()
+
+				assert.ErrorIs(t, err, ErrInvalidDID)
+				assert.ErrorContains(t, err, strings.TrimSuffix(expected, " (first error counts)"))
+			})
+		}
+	})
+}
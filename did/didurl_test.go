@@ -230,6 +230,73 @@ func TestParseDIDURL(t *testing.T) {
 	})
 }
 
+func TestDIDURL_ResolveReference(t *testing.T) {
+	base := MustParseDIDURL("did:example:123/a/b/c?q=1")
+	type testCase struct {
+		ref      string
+		expected string
+	}
+	testCases := []testCase{
+		// RFC 3986 section 5.4.1, with the DID acting as authority
+		{ref: "#frag", expected: "did:example:123/a/b/c?q=1#frag"},
+		{ref: "?y=2", expected: "did:example:123/a/b/c?y=2"},
+		{ref: "?y=2#frag", expected: "did:example:123/a/b/c?y=2#frag"},
+		{ref: "", expected: "did:example:123/a/b/c?q=1"},
+		{ref: "g", expected: "did:example:123/a/b/g"},
+		{ref: "./g", expected: "did:example:123/a/b/g"},
+		{ref: "g/", expected: "did:example:123/a/b/g/"},
+		{ref: "/g", expected: "did:example:123/g"},
+		{ref: "g?y=2", expected: "did:example:123/a/b/g?y=2"},
+		{ref: "g#s", expected: "did:example:123/a/b/g#s"},
+		{ref: ".", expected: "did:example:123/a/b/"},
+		{ref: "..", expected: "did:example:123/a/"},
+		{ref: "../g", expected: "did:example:123/a/g"},
+		{ref: "../..", expected: "did:example:123"},
+		{ref: "../../g", expected: "did:example:123/g"},
+		{ref: "../../../g", expected: "did:example:123/g"},
+		{ref: "/./g", expected: "did:example:123/g"},
+		{ref: "/../g", expected: "did:example:123/g"},
+		{ref: "g/./h", expected: "did:example:123/a/b/g/h"},
+		{ref: "g/../h", expected: "did:example:123/a/b/h"},
+		// absolute DID URLs
+		{ref: "did:other:456#key-1", expected: "did:other:456#key-1"},
+		{ref: "did:other:456/a/../b?x=y#key-1", expected: "did:other:456/b?x=y#key-1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ref, func(t *testing.T) {
+			actual, err := base.ResolveReference(tc.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+	t.Run("DID as base", func(t *testing.T) {
+		base := MustParseDID("did:example:123")
+		actual, err := base.ResolveReference("#key-1")
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:123#key-1", actual.String())
+		actual, err = base.ResolveReference("path/../other?x=y")
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:123/other?x=y", actual.String())
+	})
+	t.Run("error - relative base", func(t *testing.T) {
+		_, err := DIDURL{Fragment: "key-1"}.ResolveReference("#key-2")
+		assert.ErrorIs(t, err, ErrInvalidDID)
+		assert.EqualError(t, err, "invalid DID: base must be an absolute DID URL")
+	})
+	t.Run("error - other scheme", func(t *testing.T) {
+		_, err := base.ResolveReference("https://example.com")
+		assert.ErrorIs(t, err, ErrInvalidDID)
+	})
+	t.Run("error - authority", func(t *testing.T) {
+		_, err := base.ResolveReference("//example.com/path")
+		assert.ErrorIs(t, err, ErrInvalidDID)
+	})
+	t.Run("error - invalid DID URL", func(t *testing.T) {
+		_, err := base.ResolveReference("did:example:")
+		assert.ErrorIs(t, err, ErrInvalidDID)
+	})
+}
+
 func TestMustParseDIDURL(t *testing.T) {
 	assert.Panics(t, func() {
 		MustParseDIDURL("invalidDID")
@@ -237,13 +304,80 @@ func TestMustParseDIDURL(t *testing.T) {
 }
 
 func TestDIDURL_MarshalText(t *testing.T) {
-	const expected = "did:example:123"
+	const expected = "did:example:123/path?key=value#fragment"
 	id := MustParseDIDURL(expected)
 	actual, err := id.MarshalText()
 	assert.NoError(t, err)
 	assert.Equal(t, []byte(expected), actual)
 }
 
+func TestDIDURL_UnmarshalText(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var id DIDURL
+		err := id.UnmarshalText([]byte("did:example:123/path?key=value#fragment"))
+		require.NoError(t, err)
+		assert.Equal(t, MustParseDIDURL("did:example:123/path?key=value#fragment"), id)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var id DIDURL
+		err := id.UnmarshalText([]byte("did:example:123:"))
+		assert.ErrorIs(t, err, ErrInvalidDID)
+	})
+	t.Run("accepts the same input as UnmarshalJSON", func(t *testing.T) {
+		for _, input := range []string{"did:example:123#key-1", "", "did:example:123:", "example"} {
+			var fromText, fromJSON DIDURL
+			textErr := fromText.UnmarshalText([]byte(input))
+			jsonErr := json.Unmarshal([]byte(`"`+input+`"`), &fromJSON)
+			assert.Equal(t, jsonErr == nil, textErr == nil, input)
+			assert.Equal(t, fromJSON, fromText, input)
+		}
+	})
+}
+
+func TestDIDURL_MarshalBinary(t *testing.T) {
+	expected := MustParseDIDURL("did:example:123/path?key=value#fragment")
+	data, err := expected.MarshalBinary()
+	require.NoError(t, err)
+
+	var actual DIDURL
+	err = actual.UnmarshalBinary(data)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestDIDURL_Scan(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		var id DIDURL
+		require.NoError(t, id.Scan("did:example:123#key-1"))
+		assert.Equal(t, MustParseDIDURL("did:example:123#key-1"), id)
+	})
+	t.Run("bytes", func(t *testing.T) {
+		var id DIDURL
+		require.NoError(t, id.Scan([]byte("did:example:123#key-1")))
+		assert.Equal(t, MustParseDIDURL("did:example:123#key-1"), id)
+	})
+	t.Run("NULL", func(t *testing.T) {
+		id := MustParseDIDURL("did:example:123#key-1")
+		require.NoError(t, id.Scan(nil))
+		assert.True(t, id.Empty())
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		var id DIDURL
+		assert.EqualError(t, id.Scan(1.5), "unsupported type for DID URL: float64")
+	})
+}
+
+func TestDIDURL_Value(t *testing.T) {
+	value, err := MustParseDIDURL("did:example:123#key-1").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "did:example:123#key-1", value)
+
+	value, err = DIDURL{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
 func TestDIDURL_Equal(t *testing.T) {
 	t.Run("equal", func(t *testing.T) {
 		d1 := MustParseDIDURL("did:example:123/foo?key=value#fragment")
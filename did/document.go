@@ -2,19 +2,18 @@ package did
 
 import (
 	"crypto"
-	"crypto/ed25519"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lestrrat-go/jwx/v3/jwa"
 	"github.com/lestrrat-go/jwx/v3/jwk"
-	"github.com/multiformats/go-multibase"
 	"strings"
 
 	"github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/internal/clone"
 	"github.com/nuts-foundation/go-did/internal/marshal"
-	"github.com/shengdoushi/base58"
 )
 
 // ParseDocument parses a DID Document from a string.
@@ -68,10 +67,11 @@ type Document struct {
 type VerificationMethods []*VerificationMethod
 
 // FindByID find the first VerificationMethod which matches the provided DID.
+// IDs are compared in normalized form (see DIDURL.EqualsNormalized).
 // Returns nil when not found
 func (vms VerificationMethods) FindByID(id DIDURL) *VerificationMethod {
 	for _, vm := range vms {
-		if vm.ID.Equals(id) {
+		if vm.ID.EqualsNormalized(id) {
 			return vm
 		}
 	}
@@ -107,11 +107,11 @@ func (vms *VerificationMethods) Add(v *VerificationMethod) {
 type VerificationRelationships []VerificationRelationship
 
 // FindByID returns the first VerificationRelationship that matches with the id.
-// For comparison both the ID of the embedded VerificationMethod and reference is used.
+// For comparison both the ID of the embedded VerificationMethod and reference is used, in normalized form (see DIDURL.EqualsNormalized).
 func (vmr VerificationRelationships) FindByID(id DIDURL) *VerificationMethod {
 	for _, r := range vmr {
 		if r.VerificationMethod != nil {
-			if r.VerificationMethod.ID.Equals(id) {
+			if r.VerificationMethod.ID.EqualsNormalized(id) {
 				return r.VerificationMethod
 			}
 		}
@@ -159,6 +159,180 @@ func (d *Document) RemoveVerificationMethod(vmId DIDURL) {
 	d.KeyAgreement.Remove(vmId)
 }
 
+// RotateOption is a functional option for Document.RotateVerificationMethod.
+type RotateOption func(options *rotateOptions)
+
+type rotateOptions struct {
+	expires *time.Time
+	revoked *time.Time
+}
+
+// KeepUntil makes RotateVerificationMethod keep the old VerificationMethod in all its relationships for a grace period.
+// The end of the grace period is recorded in the `expires` property of the old VerificationMethod.
+func KeepUntil(expires time.Time) RotateOption {
+	return func(options *rotateOptions) {
+		options.expires = &expires
+	}
+}
+
+// KeepRevoked makes RotateVerificationMethod remove the old VerificationMethod from all relationships,
+// but keep it in the document's verificationMethod list (e.g. to verify signatures created before the rotation).
+// The revocation time is recorded in the `revoked` property of the old VerificationMethod.
+func KeepRevoked(revoked time.Time) RotateOption {
+	return func(options *rotateOptions) {
+		options.revoked = &revoked
+	}
+}
+
+// RotateVerificationMethod replaces the VerificationMethod identified by oldID with newVM.
+// The new VerificationMethod is added to all relationships the old one is a member of, in the same form:
+// relationships that embed the old method will embed the new method, relationships that reference it will reference the new method.
+// By default the old VerificationMethod is removed from the document; use KeepUntil or KeepRevoked to keep it.
+// If the controller of newVM is not set, it will be set to the document's ID.
+// It returns an error if the old VerificationMethod can't be found, or if newVM's ID is already present in the document.
+func (d *Document) RotateVerificationMethod(oldID DIDURL, newVM *VerificationMethod, options ...RotateOption) error {
+	opts := rotateOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+	oldID = relativeURLToAbsoluteURL(d.ID, oldID)
+	if d.findVerificationMethod(relativeURLToAbsoluteURL(d.ID, newVM.ID)) != nil {
+		return fmt.Errorf("verification method already exists: %s", newVM.ID.String())
+	}
+	oldVM := d.findVerificationMethod(oldID)
+	if oldVM == nil {
+		return fmt.Errorf("verification method not found: %s", oldID.String())
+	}
+	if newVM.Controller.Empty() {
+		newVM.Controller = d.ID
+	}
+	keepOld := opts.expires != nil && opts.revoked == nil
+
+	// Replace (or supplement) the old method in the verificationMethod list
+	var methods VerificationMethods
+	listedOld := false
+	for _, vm := range d.VerificationMethod {
+		if !relativeURLToAbsoluteURL(d.ID, vm.ID).Equals(oldID) {
+			methods = append(methods, vm)
+			continue
+		}
+		listedOld = true
+		if keepOld || opts.revoked != nil {
+			methods = append(methods, vm)
+		}
+		methods = append(methods, newVM)
+	}
+
+	// Replace (or supplement) the old method in the relationships, retaining embedded or referenced form
+	embeddedOnly := !listedOld
+	for _, relationships := range d.relationships() {
+		var updated VerificationRelationships
+		for _, rel := range *relationships {
+			if !relativeURLToAbsoluteURL(d.ID, rel.ID).Equals(oldID) {
+				updated = append(updated, rel)
+				continue
+			}
+			if keepOld {
+				updated = append(updated, rel)
+			}
+			if rel.reference.Empty() {
+				updated = append(updated, VerificationRelationship{VerificationMethod: newVM})
+			} else {
+				embeddedOnly = false
+				updated = append(updated, VerificationRelationship{newVM, newVM.ID})
+			}
+		}
+		*relationships = updated
+	}
+	if !listedOld && !embeddedOnly {
+		methods = append(methods, newVM)
+	}
+	if !listedOld && opts.revoked != nil {
+		// revoked methods are kept in the verificationMethod list, even if they were only embedded before
+		methods = append(methods, oldVM)
+	}
+	d.VerificationMethod = methods
+
+	if opts.expires != nil {
+		oldVM.Expires = opts.expires
+	}
+	if opts.revoked != nil {
+		oldVM.Revoked = opts.revoked
+	}
+	return nil
+}
+
+// findVerificationMethod returns the VerificationMethod with the given (absolute) ID,
+// either from the verificationMethod list or embedded in one of the relationships.
+func (d Document) findVerificationMethod(id DIDURL) *VerificationMethod {
+	for _, vm := range d.VerificationMethod {
+		if relativeURLToAbsoluteURL(d.ID, vm.ID).Equals(id) {
+			return vm
+		}
+	}
+	for _, relationships := range d.relationships() {
+		for _, rel := range *relationships {
+			if rel.VerificationMethod != nil && relativeURLToAbsoluteURL(d.ID, rel.ID).Equals(id) {
+				return rel.VerificationMethod
+			}
+		}
+	}
+	return nil
+}
+
+// RelationshipType identifies a verification relationship of a DID document, e.g. authentication or keyAgreement.
+type RelationshipType string
+
+const (
+	// AuthenticationRelationship identifies the authentication verification relationship.
+	AuthenticationRelationship RelationshipType = authenticationKey
+	// AssertionMethodRelationship identifies the assertionMethod verification relationship.
+	AssertionMethodRelationship RelationshipType = assertionMethodKey
+	// KeyAgreementRelationship identifies the keyAgreement verification relationship.
+	KeyAgreementRelationship RelationshipType = keyAgreementKey
+	// CapabilityInvocationRelationship identifies the capabilityInvocation verification relationship.
+	CapabilityInvocationRelationship RelationshipType = capabilityInvocationKey
+	// CapabilityDelegationRelationship identifies the capabilityDelegation verification relationship.
+	CapabilityDelegationRelationship RelationshipType = capabilityDelegationKey
+)
+
+// relationshipTypes contains all verification relationship types, in the order they're processed.
+var relationshipTypes = []RelationshipType{
+	AuthenticationRelationship,
+	AssertionMethodRelationship,
+	KeyAgreementRelationship,
+	CapabilityInvocationRelationship,
+	CapabilityDelegationRelationship,
+}
+
+// relationship returns a pointer to the verification relationship of the given type, or nil if the type is unknown.
+func (d *Document) relationship(relationshipType RelationshipType) *VerificationRelationships {
+	switch relationshipType {
+	case AuthenticationRelationship:
+		return &d.Authentication
+	case AssertionMethodRelationship:
+		return &d.AssertionMethod
+	case KeyAgreementRelationship:
+		return &d.KeyAgreement
+	case CapabilityInvocationRelationship:
+		return &d.CapabilityInvocation
+	case CapabilityDelegationRelationship:
+		return &d.CapabilityDelegation
+	}
+	return nil
+}
+
+// relationships returns pointers to all verification relationships of the document.
+func (d *Document) relationships() []*VerificationRelationships {
+	return []*VerificationRelationships{
+		&d.Authentication,
+		&d.AssertionMethod,
+		&d.KeyAgreement,
+		&d.CapabilityInvocation,
+		&d.CapabilityDelegation,
+	}
+}
+
 // AddAuthenticationMethod adds a VerificationMethod as AuthenticationMethod
 // If the controller is not set, it will be set to the document's ID
 func (d *Document) AddAuthenticationMethod(v *VerificationMethod) {
@@ -209,6 +383,55 @@ func (d *Document) AddCapabilityDelegation(v *VerificationMethod) {
 	d.CapabilityDelegation.Add(v)
 }
 
+// Clone returns a deep copy of the document.
+// Relationships of the copy point to the copied VerificationMethods, retaining pointer identity within the copy.
+func (d Document) Clone() *Document {
+	result := Document{
+		Context:     clone.Of(d.Context),
+		ID:          d.ID,
+		Controller:  clone.Of(d.Controller),
+		AlsoKnownAs: clone.Of(d.AlsoKnownAs),
+		Service:     clone.Of(d.Service),
+	}
+	copies := make(map[*VerificationMethod]*VerificationMethod)
+	cloneMethod := func(vm *VerificationMethod) *VerificationMethod {
+		if vm == nil {
+			return nil
+		}
+		if cp, ok := copies[vm]; ok {
+			return cp
+		}
+		cp := clone.Of(vm)
+		copies[vm] = cp
+		return cp
+	}
+	cloneRelationships := func(relationships VerificationRelationships) VerificationRelationships {
+		if relationships == nil {
+			return nil
+		}
+		result := make(VerificationRelationships, len(relationships))
+		for i, relationship := range relationships {
+			result[i] = VerificationRelationship{
+				VerificationMethod: cloneMethod(relationship.VerificationMethod),
+				reference:          clone.Of(relationship.reference),
+			}
+		}
+		return result
+	}
+	if d.VerificationMethod != nil {
+		result.VerificationMethod = make(VerificationMethods, len(d.VerificationMethod))
+		for i, vm := range d.VerificationMethod {
+			result.VerificationMethod[i] = cloneMethod(vm)
+		}
+	}
+	result.Authentication = cloneRelationships(d.Authentication)
+	result.AssertionMethod = cloneRelationships(d.AssertionMethod)
+	result.KeyAgreement = cloneRelationships(d.KeyAgreement)
+	result.CapabilityInvocation = cloneRelationships(d.CapabilityInvocation)
+	result.CapabilityDelegation = cloneRelationships(d.CapabilityDelegation)
+	return &result
+}
+
 func (d Document) MarshalJSON() ([]byte, error) {
 	type alias Document
 	tmp := alias(d)
@@ -229,12 +452,13 @@ func (d *Document) UnmarshalJSON(b []byte) error {
 }
 
 // IsController returns whether the given DID is a controller of the DID document.
+// DIDs are compared in normalized form (see DID.EqualsNormalized).
 func (d Document) IsController(controller DID) bool {
 	if controller.Empty() {
 		return false
 	}
 	for _, curr := range d.Controller {
-		if curr.Equals(controller) {
+		if curr.EqualsNormalized(controller) {
 			return true
 		}
 	}
@@ -242,6 +466,7 @@ func (d Document) IsController(controller DID) bool {
 }
 
 // ResolveEndpointURL finds the endpoint with the given type and unmarshalls it as single URL.
+// If the endpoint ID is relative, it is resolved against the document's ID.
 // It returns the endpoint ID and URL, or an error if anything went wrong;
 // - holder document can't be resolved,
 // - service with given type doesn't exist,
@@ -264,7 +489,7 @@ func (d *Document) ResolveEndpointURL(serviceType string) (endpointID ssi.URI, e
 	if err != nil {
 		return ssi.URI{}, "", fmt.Errorf("unable to unmarshal single URL from service (id=%s): %w", services[0].ID.String(), err)
 	}
-	return services[0].ID, endpointURL, nil
+	return relativeURIToAbsoluteURI(d.ID, services[0].ID), endpointURL, nil
 }
 
 // Service represents a DID Service as specified by the DID Core specification (https://www.w3.org/TR/did-core/#service-endpoints).
@@ -298,51 +523,54 @@ type VerificationMethod struct {
 	// PublicKeyBase58 is deprecated and should not be used anymore. Use PublicKeyMultibase or PublicKeyJwk instead.
 	PublicKeyBase58 string                 `json:"publicKeyBase58,omitempty"`
 	PublicKeyJwk    map[string]interface{} `json:"publicKeyJwk,omitempty"`
+	// PublicKeyPem contains a PEM encoded public key, as used by legacy RsaVerificationKey2018 verification methods.
+	PublicKeyPem string `json:"publicKeyPem,omitempty"`
+	// Expires indicates when the VerificationMethod expires, as specified by the Controlled Identifiers specification (https://www.w3.org/TR/cid-1.0/#verification-methods).
+	Expires *time.Time `json:"expires,omitempty"`
+	// Revoked indicates when the VerificationMethod was revoked, as specified by the Controlled Identifiers specification (https://www.w3.org/TR/cid-1.0/#verification-methods).
+	Revoked *time.Time `json:"revoked,omitempty"`
 }
 
 // NewVerificationMethod is a convenience method to easily create verificationMethods based on a set of given params.
-// It automatically encodes the provided public key based on the keyType.
-// For JsonWebKey2020 and EcdsaSecp256k1VerificationKey2019, RSA keys are rejected if their modulus is smaller
+// It automatically encodes the provided public key using the Encoder registered for the keyType (see RegisterKeyType).
+// If the keyType isn't registered, the verification method is returned without key material.
+// Private keys are rejected with ErrPrivateKeyMaterial.
+// Use WithJWKThumbprintFragment or WithMultibaseFragment to derive the ID's fragment from the key.
+// For types that encode the key as JWK (e.g. JsonWebKey2020 and RsaVerificationKey2018), RSA keys are rejected if their modulus is smaller
 // than 2048 bits. This floor is a process-wide jwx setting and can be lowered by calling
 // jwk.Configure(jwk.WithMinRSAModulusBits(n)) before any keys are parsed.
-func NewVerificationMethod(id DIDURL, keyType ssi.KeyType, controller DID, key crypto.PublicKey) (*VerificationMethod, error) {
+func NewVerificationMethod(id DIDURL, keyType ssi.KeyType, controller DID, key crypto.PublicKey, options ...VerificationMethodOption) (*VerificationMethod, error) {
+	opts := verificationMethodOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
 	vm := &VerificationMethod{
 		ID:         id,
 		Type:       keyType,
 		Controller: controller,
 	}
-
-	if keyType == ssi.JsonWebKey2020 || keyType == ssi.ECDSASECP256K1VerificationKey2019 {
-		keyAsJWK, err := jwk.Import(key)
-		if err != nil {
-			return nil, err
-		}
-		// Convert to JSON and back to fix encoding of key material to make sure
-		// an unmarshalled and newly created VerificationMethod are equal on object level.
-		// The format of PublicKeyJwk in verificationMethod is a map[string]interface{}.
-		// After unmarshalling all the fields will be map[string]string.
-		keyAsJSON, err := json.Marshal(keyAsJWK)
-		if err != nil {
-			return nil, err
-		}
-		keyAsMap := map[string]interface{}{}
-		if err := json.Unmarshal(keyAsJSON, &keyAsMap); err != nil {
-			return nil, err
-		}
-		vm.PublicKeyJwk = keyAsMap
+	if isPrivateKey(key) {
+		return nil, ErrPrivateKeyMaterial
 	}
-	if keyType == ssi.ED25519VerificationKey2018 || keyType == ssi.ED25519VerificationKey2020  {
-		ed25519Key, ok := key.(ed25519.PublicKey)
-		if !ok {
-			return nil, errors.New("wrong key type")
-		}
-		encodedKey, err := multibase.Encode(multibase.Base58BTC, ed25519Key)
+	if opts.fragment != nil {
+		fragment, err := opts.fragment(key)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("unable to derive verification method ID: %w", err)
 		}
-		vm.PublicKeyMultibase = encodedKey
+		// Thumbprints and multibase keys only contain unreserved characters, so they don't need escaping
+		vm.ID.Fragment = fragment
+		vm.ID.DecodedFragment = fragment
+	}
+	definition, ok := LookupKeyType(keyType)
+	if !ok {
+		return vm, nil
+	}
+	if err := definition.Encoder(key, vm); err != nil {
+		return nil, err
+	}
+	if err := vm.validateKeyMaterial(); err != nil {
+		return nil, err
 	}
-
 	return vm, nil
 }
 
@@ -363,43 +591,17 @@ func (v VerificationMethod) JWK() (jwk.Key, error) {
 	return key, nil
 }
 
+// PublicKey returns the public key of the verification method, decoded using the Decoder registered for its type (see RegisterKeyType).
+// It returns an error if the key material is in a property that isn't allowed for the type.
 func (v VerificationMethod) PublicKey() (crypto.PublicKey, error) {
-	var pubKey any
-	switch v.Type {
-	case ssi.ED25519VerificationKey2018, ssi.ED25519VerificationKey2020:
-		var keyBytes []byte
-		var err error
-		if v.PublicKeyMultibase != "" {
-			_, keyBytes, err = multibase.Decode(v.PublicKeyMultibase)
-			if err != nil {
-				return nil, fmt.Errorf("publicKeyMultibase decode error: %w", err)
-			}
-		} else if v.PublicKeyBase58 != "" {
-			keyBytes, err = base58.Decode(v.PublicKeyBase58, base58.BitcoinAlphabet)
-			if err != nil {
-				return nil, fmt.Errorf("publicKeyBase58 decode error: %w", err)
-			}
-		} else {
-			return nil, errors.New("expected either publicKeyMultibase or publicKeyBase58 to be set")
-		}
-		return ed25519.PublicKey(keyBytes), err
-	case ssi.ECDSASECP256K1VerificationKey2019:
-		if v.PublicKeyJwk == nil {
-			return nil, errors.New("missing publicKeyJwk")
-		}
-		fallthrough
-	case ssi.JsonWebKey2020:
-		keyAsJWK, err := v.JWK()
-		if err != nil {
-			return nil, err
-		}
-		err = jwk.Export(keyAsJWK, &pubKey)
-		if err != nil {
-			return nil, err
-		}
-		return pubKey, nil
+	definition, ok := LookupKeyType(v.Type)
+	if !ok {
+		return nil, errors.New("unsupported verification method type")
 	}
-	return nil, errors.New("unsupported verification method type")
+	if err := definition.checkKeyMaterial(v); err != nil {
+		return nil, err
+	}
+	return definition.Decoder(v)
 }
 
 // VerificationRelationship represents the usage of a VerificationMethod e.g. in authentication, assertionMethod, or keyAgreement.
@@ -463,13 +665,16 @@ func (v *VerificationMethod) UnmarshalJSON(bytes []byte) error {
 		PublicKeyMultibase string                 `json:"publicKeyMultibase,omitempty"`
 		PublicKeyBase58    string                 `json:"publicKeyBase58,omitempty"`
 		PublicKeyJwk       map[string]interface{} `json:"publicKeyJwk,omitempty"`
+		PublicKeyPem       string                 `json:"publicKeyPem,omitempty"`
+		Expires            *time.Time             `json:"expires,omitempty"`
+		Revoked            *time.Time             `json:"revoked,omitempty"`
 	}
 	var tmp alias
 	if err := json.Unmarshal(bytes, &tmp); err != nil {
 		return err
 	}
 
-	// publicKeyJWK, publicKeyBase58 and publicKeyMultibase are all mutually exclusive
+	// publicKeyJWK, publicKeyBase58, publicKeyMultibase and publicKeyPem are all mutually exclusive
 	countPresent := 0
 	if len(tmp.PublicKeyJwk) > 0 {
 		countPresent++
@@ -480,22 +685,32 @@ func (v *VerificationMethod) UnmarshalJSON(bytes []byte) error {
 	if len(tmp.PublicKeyMultibase) > 0 {
 		countPresent++
 	}
+	if len(tmp.PublicKeyPem) > 0 {
+		countPresent++
+	}
 	if countPresent > 1 {
-		return errors.New("only one of publicKeyJWK, publicKeyBase58 and publicKeyMultibase can be present")
+		return errors.New("only one of publicKeyJWK, publicKeyBase58, publicKeyMultibase and publicKeyPem can be present")
 	}
 
 	id, err := ParseDIDURL(tmp.ID)
 	if err != nil {
 		return fmt.Errorf("invalid id: %w", err)
 	}
-	*v = VerificationMethod{
+	result := VerificationMethod{
 		ID:                 *id,
 		Type:               tmp.Type,
 		Controller:         tmp.Controller,
 		PublicKeyMultibase: tmp.PublicKeyMultibase,
 		PublicKeyBase58:    tmp.PublicKeyBase58,
 		PublicKeyJwk:       tmp.PublicKeyJwk,
+		PublicKeyPem:       tmp.PublicKeyPem,
+		Expires:            tmp.Expires,
+		Revoked:            tmp.Revoked,
+	}
+	if err := result.validateKeyMaterial(); err != nil {
+		return fmt.Errorf("invalid key material of verification method %s: %w", tmp.ID, err)
 	}
+	*v = result
 	return nil
 }
 
@@ -515,15 +730,32 @@ func resolveVerificationRelationships(baseURI DID, relationships []VerificationR
 	return nil
 }
 
-// relativeURLToAbsoluteURL converts the reference to an absolute URL if it is relative.
-// This means it copies the base DID to the reference (if not set in the reference).
+// relativeURLToAbsoluteURL converts the reference to an absolute URL if it is relative,
+// by resolving it against the base DID (see DID.ResolveReference). Dot segments are removed from the path.
+// The result is normalized (see DIDURL.Normalize), so equivalent references compare equal.
+// If the reference can't be resolved, it is returned normalized but otherwise as-is.
 func relativeURLToAbsoluteURL(baseURI DID, ref DIDURL) DIDURL {
-	if ref.ID == "" {
-		// reference is relative to base URI (DID subject ID)
-		ref.Method = baseURI.Method
-		ref.ID = baseURI.ID
+	if baseURI.Empty() {
+		return ref.Normalize()
+	}
+	resolved, err := baseURI.ResolveReference(ref.String())
+	if err != nil {
+		return ref.Normalize()
+	}
+	return resolved.Normalize()
+}
+
+// relativeURIToAbsoluteURI converts a service ID to an absolute URI if it is relative, by resolving it against the base DID.
+// If the ID is absolute (has a scheme) or can't be resolved, it is returned as-is.
+func relativeURIToAbsoluteURI(baseURI DID, ref ssi.URI) ssi.URI {
+	if ref.Scheme != "" || baseURI.Empty() {
+		return ref
+	}
+	resolved, err := baseURI.ResolveReference(ref.String())
+	if err != nil {
+		return ref
 	}
-	return ref
+	return ssi.MustParseURI(resolved.String())
 }
 
 func resolveVerificationRelationship(baseURI DID, reference DIDURL, methods []*VerificationMethod) *VerificationRelationship {
@@ -1,6 +1,8 @@
 package did
 
 import (
+	"crypto"
+	"crypto/ecdh"
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
@@ -9,9 +11,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/multiformats/go-multibase"
 	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/multicodec"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -176,7 +182,7 @@ func Test_Document(t *testing.T) {
 			privateKey, err := secp256k1.GeneratePrivateKey()
 			require.NoError(t, err)
 
-			vm, err := NewVerificationMethod(keyID, ssi.ECDSASECP256K1VerificationKey2019, actual.ID, privateKey.ToECDSA())
+			vm, err := NewVerificationMethod(keyID, ssi.ECDSASECP256K1VerificationKey2019, actual.ID, privateKey.PubKey().ToECDSA())
 			require.NoError(t, err)
 
 			publicKey, err := vm.PublicKey()
@@ -453,12 +459,36 @@ func TestParseDocument(t *testing.T) {
 				relationshipID:       "#abc",
 				mustResolve:          true,
 			},
+			{
+				name:                 "resolve, relative relationship with path",
+				verificationMethodID: "did:example:123/keys#abc",
+				relationshipID:       "/keys#abc",
+				mustResolve:          true,
+			},
+			{
+				name:                 "resolve, relative relationship with query",
+				verificationMethodID: "did:example:123?service=keys#abc",
+				relationshipID:       "?service=keys#abc",
+				mustResolve:          true,
+			},
+			{
+				name:                 "resolve, dot segments in verification method ID",
+				verificationMethodID: "did:example:123/a/../keys#abc",
+				relationshipID:       "/keys#abc",
+				mustResolve:          true,
+			},
 			{
 				name:                 "no resolve, relationship ID does not match verification method ID",
 				verificationMethodID: "did:example:123#abc",
 				relationshipID:       "#def",
 				mustResolve:          false,
 			},
+			{
+				name:                 "no resolve, relationship path does not match verification method path",
+				verificationMethodID: "did:example:123/keys#abc",
+				relationshipID:       "#abc",
+				mustResolve:          false,
+			},
 		}
 		subjectID := MustParseDID("did:example:123")
 		for _, tc := range testCases {
@@ -558,6 +588,137 @@ func TestDocument_RemoveVerificationMethod(t *testing.T) {
 	})
 }
 
+func TestDocument_Clone(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		original, err := ParseDocument(string(test.ReadTestFile("test/did1.json")))
+		require.NoError(t, err)
+
+		actual := original.Clone()
+
+		assert.Equal(t, original, actual)
+		expectedJSON, _ := json.Marshal(original)
+		actualJSON, _ := json.Marshal(actual)
+		assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+		assert.NotSame(t, original.VerificationMethod[0], actual.VerificationMethod[0])
+		// relationships must point to the cloned verification methods
+		assert.Same(t, actual.VerificationMethod[0], actual.AssertionMethod[0].VerificationMethod)
+		assert.Same(t, actual.VerificationMethod[0], actual.Authentication[0].VerificationMethod)
+		// altering the clone must not alter the original
+		actual.VerificationMethod[0].PublicKeyJwk["x"] = "changed"
+		actual.Service[0].ServiceEndpoint = "changed"
+		actual.Controller[0] = MustParseDID("did:example:changed")
+		assert.Equal(t, "SVqB4JcUD6lsfvqMr-OKUNUphdNn64Eay60978ZlL74", original.VerificationMethod[0].PublicKeyJwk["x"])
+		assert.NotEqual(t, "changed", original.Service[0].ServiceEndpoint)
+		assert.Equal(t, "did:nuts:04cf1e20-378a-4e38-ab1b-401a5018c9ff", original.Controller[0].String())
+	})
+	t.Run("embedded verification method", func(t *testing.T) {
+		original := Document{ID: MustParseDID("did:example:123")}
+		original.Authentication = VerificationRelationships{{VerificationMethod: &VerificationMethod{ID: MustParseDIDURL("did:example:123#key-1")}}}
+
+		actual := original.Clone()
+
+		assert.Equal(t, original, *actual)
+		assert.NotSame(t, original.Authentication[0].VerificationMethod, actual.Authentication[0].VerificationMethod)
+	})
+	t.Run("empty document", func(t *testing.T) {
+		assert.Equal(t, Document{}, *Document{}.Clone())
+	})
+}
+
+func TestDocument_RotateVerificationMethod(t *testing.T) {
+	oldID := MustParseDIDURL("did:example:123#key-1")
+	newID := MustParseDIDURL("did:example:123#key-2")
+	createDocument := func() (*Document, *VerificationMethod) {
+		doc := &Document{ID: MustParseDID("did:example:123")}
+		vm := &VerificationMethod{ID: oldID, Type: ssi.JsonWebKey2020}
+		doc.AddAssertionMethod(vm)
+		doc.AddAuthenticationMethod(vm)
+		return doc, vm
+	}
+
+	t.Run("ok - old method is removed", func(t *testing.T) {
+		doc, _ := createDocument()
+		doc.AddKeyAgreement(&VerificationMethod{ID: MustParseDIDURL("did:example:123#other")})
+		newVM := &VerificationMethod{ID: newID, Type: ssi.JsonWebKey2020}
+
+		err := doc.RotateVerificationMethod(oldID, newVM)
+
+		require.NoError(t, err)
+		require.Len(t, doc.VerificationMethod, 2)
+		assert.Same(t, newVM, doc.VerificationMethod[0])
+		assert.Same(t, newVM, doc.AssertionMethod.FindByID(newID))
+		assert.Same(t, newVM, doc.Authentication.FindByID(newID))
+		assert.Nil(t, doc.AssertionMethod.FindByID(oldID))
+		assert.Nil(t, doc.Authentication.FindByID(oldID))
+		assert.Nil(t, doc.KeyAgreement.FindByID(newID), "new method must not be added to other relationships")
+		assert.Equal(t, doc.ID, newVM.Controller)
+	})
+	t.Run("ok - keep old method for a grace period", func(t *testing.T) {
+		doc, oldVM := createDocument()
+		newVM := &VerificationMethod{ID: newID}
+		expires := time.Now().Add(time.Hour)
+
+		err := doc.RotateVerificationMethod(oldID, newVM, KeepUntil(expires))
+
+		require.NoError(t, err)
+		assert.Len(t, doc.VerificationMethod, 2)
+		assert.Len(t, doc.AssertionMethod, 2)
+		assert.Len(t, doc.Authentication, 2)
+		assert.Equal(t, expires, *oldVM.Expires)
+		assert.Nil(t, oldVM.Revoked)
+	})
+	t.Run("ok - keep old method as revoked", func(t *testing.T) {
+		doc, oldVM := createDocument()
+		newVM := &VerificationMethod{ID: newID}
+		revoked := time.Now()
+
+		err := doc.RotateVerificationMethod(oldID, newVM, KeepRevoked(revoked))
+
+		require.NoError(t, err)
+		assert.Len(t, doc.VerificationMethod, 2)
+		assert.Same(t, oldVM, doc.VerificationMethod.FindByID(oldID))
+		assert.Nil(t, doc.AssertionMethod.FindByID(oldID))
+		assert.Nil(t, doc.Authentication.FindByID(oldID))
+		assert.Equal(t, revoked, *oldVM.Revoked)
+	})
+	t.Run("ok - embedded method stays embedded", func(t *testing.T) {
+		doc := &Document{ID: MustParseDID("did:example:123")}
+		doc.Authentication = VerificationRelationships{{VerificationMethod: &VerificationMethod{ID: oldID}}}
+		newVM := &VerificationMethod{ID: newID}
+
+		err := doc.RotateVerificationMethod(oldID, newVM)
+
+		require.NoError(t, err)
+		assert.Empty(t, doc.VerificationMethod)
+		require.Len(t, doc.Authentication, 1)
+		assert.True(t, doc.Authentication[0].reference.Empty())
+		asJSON, _ := json.Marshal(doc.Authentication[0])
+		assert.Contains(t, string(asJSON), `"id":"did:example:123#key-2"`)
+	})
+	t.Run("ok - relative ID", func(t *testing.T) {
+		doc, _ := createDocument()
+
+		err := doc.RotateVerificationMethod(DIDURL{Fragment: "key-1"}, &VerificationMethod{ID: newID})
+
+		require.NoError(t, err)
+		assert.Nil(t, doc.VerificationMethod.FindByID(oldID))
+	})
+	t.Run("error - old method not found", func(t *testing.T) {
+		doc, _ := createDocument()
+
+		err := doc.RotateVerificationMethod(MustParseDIDURL("did:example:123#unknown"), &VerificationMethod{ID: newID})
+
+		assert.EqualError(t, err, "verification method not found: did:example:123#unknown")
+	})
+	t.Run("error - new method already exists", func(t *testing.T) {
+		doc, _ := createDocument()
+
+		err := doc.RotateVerificationMethod(oldID, &VerificationMethod{ID: oldID})
+
+		assert.EqualError(t, err, "verification method already exists: did:example:123#key-1")
+	})
+}
+
 func TestVerificationRelationship_UnmarshalJSON(t *testing.T) {
 	t.Run("ok - unmarshal single did", func(t *testing.T) {
 		input := `"did:nuts:123#key-1"`
@@ -596,6 +757,82 @@ func TestNewVerificationMethod(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, expectedKey, actualKey)
 	})
+	t.Run("Multikey", func(t *testing.T) {
+		id := MustParseDIDURL("did:example:123#1")
+		ed25519Key, _, _ := ed25519.GenerateKey(rand.Reader)
+		x25519Key, _ := ecdh.X25519().GenerateKey(rand.Reader)
+		p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		secp256k1Key, _ := secp256k1.GeneratePrivateKey()
+		testCases := []struct {
+			name   string
+			key    crypto.PublicKey
+			prefix string
+		}{
+			{name: "Ed25519", key: ed25519Key, prefix: "z6Mk"},
+			{name: "X25519", key: x25519Key.PublicKey(), prefix: "z6LS"},
+			{name: "P-256", key: &p256Key.PublicKey, prefix: "zDn"},
+			{name: "P-384", key: &p384Key.PublicKey, prefix: "z82"},
+			{name: "secp256k1", key: secp256k1Key.PubKey().ToECDSA(), prefix: "zQ3s"},
+			{name: "BLS12-381 G2", key: multicodec.BLS12381G2PublicKey(make([]byte, 96)), prefix: "zUC"},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				vm, err := NewVerificationMethod(id, ssi.Multikey, id.DID, testCase.key)
+				require.NoError(t, err)
+				assert.Equal(t, ssi.Multikey, vm.Type)
+				assert.True(t, strings.HasPrefix(vm.PublicKeyMultibase, testCase.prefix), vm.PublicKeyMultibase)
+				assert.Nil(t, vm.PublicKeyJwk)
+
+				actualKey, err := vm.PublicKey()
+				require.NoError(t, err)
+				assert.Equal(t, testCase.key, actualKey)
+			})
+		}
+	})
+	t.Run("Multikey - unsupported key type", func(t *testing.T) {
+		id := MustParseDIDURL("did:example:123#1")
+		privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		_, err := NewVerificationMethod(id, ssi.Multikey, id.DID, &privateKey.PublicKey)
+		assert.ErrorIs(t, err, multicodec.ErrUnsupportedKey)
+	})
+}
+
+func TestVerificationMethod_PublicKey(t *testing.T) {
+	t.Run("Multikey", func(t *testing.T) {
+		// Test vector from https://www.w3.org/TR/cid-1.0/#Multikey
+		vm := VerificationMethod{
+			Type:               ssi.Multikey,
+			PublicKeyMultibase: "z6MkmM42vxfqZQsv4ehtTjFFxQ4sQKS2w6WR7emozFAn5cxu",
+		}
+		publicKey, err := vm.PublicKey()
+		require.NoError(t, err)
+		assert.IsType(t, ed25519.PublicKey{}, publicKey)
+	})
+	t.Run("Multikey - missing publicKeyMultibase", func(t *testing.T) {
+		_, err := VerificationMethod{Type: ssi.Multikey}.PublicKey()
+		assert.EqualError(t, err, "missing publicKeyMultibase")
+	})
+	t.Run("Multikey - invalid multibase", func(t *testing.T) {
+		_, err := VerificationMethod{Type: ssi.Multikey, PublicKeyMultibase: "foo"}.PublicKey()
+		assert.ErrorContains(t, err, "publicKeyMultibase decode error")
+	})
+	t.Run("Multikey - unsupported multicodec", func(t *testing.T) {
+		encoded, _ := multibase.Encode(multibase.Base58BTC, multicodec.Encode(0x1205, make([]byte, 32)))
+		_, err := VerificationMethod{Type: ssi.Multikey, PublicKeyMultibase: encoded}.PublicKey()
+		assert.ErrorIs(t, err, multicodec.ErrUnsupportedCode)
+	})
+	t.Run("Ed25519VerificationKey2020 with multicodec prefix", func(t *testing.T) {
+		expected, _, _ := ed25519.GenerateKey(rand.Reader)
+		encoded, _ := multibase.Encode(multibase.Base58BTC, multicodec.Encode(multicodec.Ed25519Pub, expected))
+		vm := VerificationMethod{
+			Type:               ssi.ED25519VerificationKey2020,
+			PublicKeyMultibase: encoded,
+		}
+		publicKey, err := vm.PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, expected, publicKey)
+	})
 }
 
 func TestVerificationMethod_UnmarshalJSON(t *testing.T) {
@@ -608,7 +845,7 @@ func TestVerificationMethod_UnmarshalJSON(t *testing.T) {
 		})
 		actual := VerificationMethod{}
 		err := json.Unmarshal(input, &actual)
-		assert.EqualError(t, err, "only one of publicKeyJWK, publicKeyBase58 and publicKeyMultibase can be present")
+		assert.EqualError(t, err, "only one of publicKeyJWK, publicKeyBase58, publicKeyMultibase and publicKeyPem can be present")
 	})
 	t.Run("all of publicKeyJWK, publicKeyMultibase and publicKeyBase58 are present", func(t *testing.T) {
 		input, _ := json.Marshal(VerificationMethod{
@@ -620,7 +857,7 @@ func TestVerificationMethod_UnmarshalJSON(t *testing.T) {
 		})
 		actual := VerificationMethod{}
 		err := json.Unmarshal(input, &actual)
-		assert.EqualError(t, err, "only one of publicKeyJWK, publicKeyBase58 and publicKeyMultibase can be present")
+		assert.EqualError(t, err, "only one of publicKeyJWK, publicKeyBase58, publicKeyMultibase and publicKeyPem can be present")
 	})
 }
 
@@ -841,6 +1078,17 @@ func TestDocument_ResolveEndpointURL(t *testing.T) {
 		assert.Equal(t, "did:example:123#linked-domain", endpointID.String())
 	})
 
+	t.Run("ok - relative ID", func(t *testing.T) {
+		doc := Document{}
+		json.Unmarshal([]byte(jsonDoc), &doc)
+		doc.Service[0].ID = ssi.MustParseURI("#linked-domain")
+
+		endpointID, _, err := doc.ResolveEndpointURL("custom")
+
+		require.NoError(t, err)
+		assert.Equal(t, "did:web:identity.foundation#linked-domain", endpointID.String())
+	})
+
 	t.Run("no services match", func(t *testing.T) {
 		doc := Document{}
 		json.Unmarshal([]byte(jsonDoc), &doc)
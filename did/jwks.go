@@ -0,0 +1,202 @@
+package did
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	ssi "github.com/nuts-foundation/go-did"
+)
+
+// JWKS returns the public keys of the document's verification methods as JWK Set (RFC 7517).
+// If relationship is empty, all verification methods of the document are included (including embedded ones),
+// otherwise only the verification methods of the given relationship are included.
+// The `kid` of each key is set to the (absolute) ID of the verification method.
+// The `use` and `key_ops` parameters are derived from the relationships the verification method is a member of:
+// keyAgreement results in `enc` (deriveKey), all other relationships result in `sig` (verify).
+// If a verification method is used for both signing and key agreement, `use` is omitted.
+// It returns an error if the key of one of the verification methods can't be converted to a JWK.
+func (d Document) JWKS(relationship RelationshipType) (jwk.Set, error) {
+	var methods []*VerificationMethod
+	if relationship == "" {
+		methods = append(methods, d.VerificationMethod...)
+		for _, relationships := range d.relationships() {
+			for _, rel := range *relationships {
+				if rel.VerificationMethod != nil && rel.reference.Empty() {
+					methods = append(methods, rel.VerificationMethod)
+				}
+			}
+		}
+	} else {
+		relationships := d.relationship(relationship)
+		if relationships == nil {
+			return nil, fmt.Errorf("unknown verification relationship: %s", relationship)
+		}
+		for _, rel := range *relationships {
+			if rel.VerificationMethod != nil {
+				methods = append(methods, rel.VerificationMethod)
+			}
+		}
+	}
+
+	result := jwk.NewSet()
+	added := make(map[string]bool)
+	for _, vm := range methods {
+		id := relativeURLToAbsoluteURL(d.ID, vm.ID)
+		if added[id.String()] {
+			continue
+		}
+		added[id.String()] = true
+		key, err := vm.publicJWK()
+		if err != nil {
+			return nil, fmt.Errorf("verification method %s: %w", id.String(), err)
+		}
+		if err = key.Set(jwk.KeyIDKey, id.String()); err != nil {
+			return nil, err
+		}
+		var keyOps jwk.KeyOperationList
+		var usages []jwk.KeyUsageType
+		if d.hasRelationship(id, AuthenticationRelationship, AssertionMethodRelationship, CapabilityInvocationRelationship, CapabilityDelegationRelationship) {
+			keyOps = append(keyOps, jwk.KeyOpVerify)
+			usages = append(usages, jwk.ForSignature)
+		}
+		if d.hasRelationship(id, KeyAgreementRelationship) {
+			keyOps = append(keyOps, jwk.KeyOpDeriveKey)
+			usages = append(usages, jwk.ForEncryption)
+		}
+		_ = key.Remove(jwk.KeyUsageKey)
+		_ = key.Remove(jwk.KeyOpsKey)
+		if len(usages) == 1 {
+			if err = key.Set(jwk.KeyUsageKey, usages[0]); err != nil {
+				return nil, err
+			}
+		}
+		if len(keyOps) > 0 {
+			if err = key.Set(jwk.KeyOpsKey, keyOps); err != nil {
+				return nil, err
+			}
+		}
+		if err = result.AddKey(key); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// hasRelationship returns whether the verification method with the given (absolute) ID is a member of any of the given relationships.
+func (d Document) hasRelationship(id DIDURL, relationshipTypes ...RelationshipType) bool {
+	for _, relationshipType := range relationshipTypes {
+		for _, rel := range *d.relationship(relationshipType) {
+			if relativeURLToAbsoluteURL(d.ID, rel.ID).Equals(id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// publicJWK returns the public key of the verification method as JWK.
+func (v VerificationMethod) publicJWK() (jwk.Key, error) {
+	var key jwk.Key
+	var err error
+	if v.PublicKeyJwk != nil {
+		key, err = v.JWK()
+	} else {
+		var publicKey crypto.PublicKey
+		publicKey, err = v.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		key, err = jwk.Import(publicKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jwk.PublicKeyOf(key)
+}
+
+// NewDocumentFromJWKS creates a DID document for the given DID from a JWK Set (RFC 7517).
+// Every key in the set results in a JsonWebKey2020 verification method. The ID of the verification method is derived from the `kid`:
+// - if the `kid` is a DID URL (absolute or relative), it is resolved against the DID and used as ID,
+// - if the `kid` is another non-empty value, it is used as fragment,
+// - if the `kid` is empty or not a valid fragment, the base64url-encoded RFC 7638 JWK thumbprint (SHA-256) is used as fragment.
+//
+// Keys are added to relationships according to their `use` parameter: `sig` results in authentication and assertionMethod,
+// `enc` results in keyAgreement. If `use` is absent, `key_ops` is used instead (`verify` for signing, `deriveKey`, `deriveBits`,
+// `encrypt` and `wrapKey` for key agreement). Keys without either are used for signing.
+// It returns an error if the set contains private or symmetric keys, or if a `kid` refers to another DID.
+func NewDocumentFromJWKS(id DID, set jwk.Set) (*Document, error) {
+	document := &Document{
+		Context: []interface{}{DIDContextV1URI()},
+		ID:      id,
+	}
+	for i := 0; i < set.Len(); i++ {
+		key, _ := set.Key(i)
+		switch key.(type) {
+		case jwk.RSAPrivateKey, jwk.ECDSAPrivateKey, jwk.OKPPrivateKey, jwk.SymmetricKey:
+			return nil, fmt.Errorf("key %d: only public keys are allowed", i)
+		}
+		vmID, err := keyIDToDIDURL(id, key)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		var publicKey crypto.PublicKey
+		if err = jwk.Export(key, &publicKey); err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		vm, err := NewVerificationMethod(*vmID, ssi.JsonWebKey2020, id, publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		signing, keyAgreement := keyUsage(key)
+		if signing {
+			document.AddAuthenticationMethod(vm)
+			document.AddAssertionMethod(vm)
+		}
+		if keyAgreement {
+			document.AddKeyAgreement(vm)
+		}
+	}
+	return document, nil
+}
+
+func keyIDToDIDURL(id DID, key jwk.Key) (*DIDURL, error) {
+	if kid, ok := key.KeyID(); ok && kid != "" {
+		if parsed, err := ParseDIDURL(kid); err == nil {
+			result := relativeURLToAbsoluteURL(id, *parsed)
+			if !result.DID.Equals(id) {
+				return nil, fmt.Errorf("key ID refers to another DID: %s", kid)
+			}
+			return &result, nil
+		}
+		if result, err := id.ResolveReference("#" + kid); err == nil {
+			return result, nil
+		}
+	}
+	thumbprint, err := jwkThumbprint(key)
+	if err != nil {
+		return nil, err
+	}
+	return id.ResolveReference("#" + thumbprint)
+}
+
+// keyUsage derives whether the key is intended for signing and/or key agreement from the `use` or `key_ops` parameters.
+func keyUsage(key jwk.Key) (signing bool, keyAgreement bool) {
+	if usage, ok := key.KeyUsage(); ok {
+		return usage == jwk.ForSignature.String(), usage == jwk.ForEncryption.String()
+	}
+	if keyOps, ok := key.KeyOps(); ok {
+		for _, keyOp := range keyOps {
+			switch keyOp {
+			case jwk.KeyOpVerify:
+				signing = true
+			case jwk.KeyOpDeriveKey, jwk.KeyOpDeriveBits, jwk.KeyOpEncrypt, jwk.KeyOpWrapKey:
+				keyAgreement = true
+			}
+		}
+		if signing || keyAgreement {
+			return
+		}
+	}
+	return true, false
+}
@@ -0,0 +1,169 @@
+package did
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_JWKS(t *testing.T) {
+	id := MustParseDID("did:example:123")
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	signingVM, _ := NewVerificationMethod(MustParseDIDURL("did:example:123#signing"), ssi.JsonWebKey2020, id, ecKey.Public())
+	edVM, _ := NewVerificationMethod(MustParseDIDURL("did:example:123#ed25519"), ssi.ED25519VerificationKey2020, id, edKey)
+	encryptionVM, _ := NewVerificationMethod(DIDURL{Fragment: "encryption"}, ssi.JsonWebKey2020, id, ecKey.Public())
+	document := Document{ID: id}
+	document.AddAssertionMethod(signingVM)
+	document.AddAuthenticationMethod(edVM)
+	document.AddKeyAgreement(edVM)
+	document.AddKeyAgreement(encryptionVM)
+
+	t.Run("all verification methods", func(t *testing.T) {
+		set, err := document.JWKS("")
+
+		require.NoError(t, err)
+		require.Equal(t, 3, set.Len())
+		signingKey, ok := set.LookupKeyID("did:example:123#signing")
+		require.True(t, ok)
+		usage, _ := signingKey.KeyUsage()
+		assert.Equal(t, "sig", usage)
+		keyOps, _ := signingKey.KeyOps()
+		assert.Equal(t, jwk.KeyOperationList{jwk.KeyOpVerify}, keyOps)
+
+		edJWK, ok := set.LookupKeyID("did:example:123#ed25519")
+		require.True(t, ok)
+		assert.Implements(t, (*jwk.OKPPublicKey)(nil), edJWK)
+		_, hasUsage := edJWK.KeyUsage()
+		assert.False(t, hasUsage, "use must be omitted for keys used for both signing and encryption")
+		keyOps, _ = edJWK.KeyOps()
+		assert.Equal(t, jwk.KeyOperationList{jwk.KeyOpVerify, jwk.KeyOpDeriveKey}, keyOps)
+
+		encryptionKey, ok := set.LookupKeyID("did:example:123#encryption")
+		require.True(t, ok, "relative ID must be resolved")
+		usage, _ = encryptionKey.KeyUsage()
+		assert.Equal(t, "enc", usage)
+	})
+	t.Run("single relationship", func(t *testing.T) {
+		set, err := document.JWKS(KeyAgreementRelationship)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, set.Len())
+		_, ok := set.LookupKeyID("did:example:123#signing")
+		assert.False(t, ok)
+	})
+	t.Run("no private key material", func(t *testing.T) {
+		set, err := document.JWKS("")
+		require.NoError(t, err)
+
+		asJSON, _ := json.Marshal(set)
+
+		assert.NotContains(t, string(asJSON), `"d"`)
+	})
+	t.Run("unknown relationship", func(t *testing.T) {
+		_, err := document.JWKS("unknown")
+
+		assert.EqualError(t, err, "unknown verification relationship: unknown")
+	})
+	t.Run("unsupported verification method", func(t *testing.T) {
+		document := Document{ID: id}
+		document.AddAssertionMethod(&VerificationMethod{ID: MustParseDIDURL("did:example:123#1"), Type: "unknown"})
+
+		_, err := document.JWKS("")
+
+		assert.EqualError(t, err, "verification method did:example:123#1: unsupported verification method type")
+	})
+}
+
+func TestNewDocumentFromJWKS(t *testing.T) {
+	id := MustParseDID("did:example:123")
+	newKey := func(t *testing.T, kid string, usage string) jwk.Key {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		key, err := jwk.Import(privateKey.Public())
+		require.NoError(t, err)
+		if kid != "" {
+			require.NoError(t, key.Set(jwk.KeyIDKey, kid))
+		}
+		if usage != "" {
+			require.NoError(t, key.Set(jwk.KeyUsageKey, usage))
+		}
+		return key
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		set := jwk.NewSet()
+		_ = set.AddKey(newKey(t, "did:example:123#abs", "sig"))
+		_ = set.AddKey(newKey(t, "#rel", "enc"))
+		_ = set.AddKey(newKey(t, "plain", ""))
+		thumbprintKey := newKey(t, "", "")
+		_ = set.AddKey(thumbprintKey)
+
+		document, err := NewDocumentFromJWKS(id, set)
+
+		require.NoError(t, err)
+		assert.Equal(t, id, document.ID)
+		require.Len(t, document.VerificationMethod, 4)
+		assert.Equal(t, "did:example:123#abs", document.VerificationMethod[0].ID.String())
+		assert.Equal(t, "did:example:123#rel", document.VerificationMethod[1].ID.String())
+		assert.Equal(t, "did:example:123#plain", document.VerificationMethod[2].ID.String())
+		thumbprint, _ := thumbprintKey.Thumbprint(crypto.SHA256)
+		assert.Equal(t, "did:example:123#"+base64.RawURLEncoding.EncodeToString(thumbprint), document.VerificationMethod[3].ID.String())
+		for _, vm := range document.VerificationMethod {
+			assert.Equal(t, ssi.JsonWebKey2020, vm.Type)
+			assert.Equal(t, id, vm.Controller)
+		}
+		assert.Len(t, document.Authentication, 3)
+		assert.Len(t, document.AssertionMethod, 3)
+		require.Len(t, document.KeyAgreement, 1)
+		assert.Equal(t, "did:example:123#rel", document.KeyAgreement[0].ID.String())
+		require.NoError(t, W3CSpecValidator{}.Validate(*document))
+	})
+	t.Run("round trip", func(t *testing.T) {
+		set := jwk.NewSet()
+		_ = set.AddKey(newKey(t, "did:example:123#1", "sig"))
+		_ = set.AddKey(newKey(t, "did:example:123#2", "enc"))
+		document, err := NewDocumentFromJWKS(id, set)
+		require.NoError(t, err)
+
+		actual, err := document.JWKS("")
+
+		require.NoError(t, err)
+		expectedJSON, _ := json.Marshal(set)
+		actualJSON, _ := json.Marshal(actual)
+		var expected, result map[string][]map[string]interface{}
+		_ = json.Unmarshal(expectedJSON, &expected)
+		_ = json.Unmarshal(actualJSON, &result)
+		for i := range result["keys"] {
+			delete(result["keys"][i], "key_ops")
+		}
+		assert.Equal(t, expected, result)
+	})
+	t.Run("private key", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		key, _ := jwk.Import(privateKey)
+		set := jwk.NewSet()
+		_ = set.AddKey(key)
+
+		_, err := NewDocumentFromJWKS(id, set)
+
+		assert.EqualError(t, err, "key 0: only public keys are allowed")
+	})
+	t.Run("key ID refers to other DID", func(t *testing.T) {
+		set := jwk.NewSet()
+		_ = set.AddKey(newKey(t, "did:example:456#1", ""))
+
+		_, err := NewDocumentFromJWKS(id, set)
+
+		assert.EqualError(t, err, "key 0: key ID refers to another DID: did:example:456#1")
+	})
+}
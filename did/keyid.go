@@ -0,0 +1,116 @@
+package did
+
+import (
+	"crypto"
+	"encoding/base64"
+
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/multiformats/go-multibase"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/multicodec"
+)
+
+// VerificationMethodOption is an option for NewVerificationMethod.
+type VerificationMethodOption func(options *verificationMethodOptions)
+
+type verificationMethodOptions struct {
+	fragment func(key crypto.PublicKey) (string, error)
+}
+
+// WithJWKThumbprintFragment derives the fragment of the verification method ID from the key's JWK thumbprint (see JWKThumbprint),
+// replacing the fragment of the given ID.
+func WithJWKThumbprintFragment() VerificationMethodOption {
+	return func(options *verificationMethodOptions) {
+		options.fragment = JWKThumbprint
+	}
+}
+
+// WithMultibaseFragment derives the fragment of the verification method ID from the key's multibase encoding (see MultibaseKey),
+// replacing the fragment of the given ID. This is the fragment did:key uses.
+func WithMultibaseFragment() VerificationMethodOption {
+	return func(options *verificationMethodOptions) {
+		options.fragment = MultibaseKey
+	}
+}
+
+// JWKThumbprint returns the RFC 7638 JWK thumbprint of the given public key, using SHA-256 and base64url encoding.
+func JWKThumbprint(key crypto.PublicKey) (string, error) {
+	keyAsJWK, err := jwk.Import(key)
+	if err != nil {
+		return "", err
+	}
+	return jwkThumbprint(keyAsJWK)
+}
+
+// MultibaseKey returns the base58btc multibase encoding of the multicodec-prefixed public key, as used by Multikey and did:key.
+func MultibaseKey(key crypto.PublicKey) (string, error) {
+	keyBytes, err := multicodec.EncodePublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	return multibase.Encode(multibase.Base58BTC, keyBytes)
+}
+
+func jwkThumbprint(key jwk.Key) (string, error) {
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// JWKThumbprint returns the RFC 7638 JWK thumbprint (SHA-256, base64url encoded) of the verification method's public key,
+// regardless of how the key is encoded in the verification method.
+func (v VerificationMethod) JWKThumbprint() (string, error) {
+	key, err := v.publicJWK()
+	if err != nil {
+		return "", err
+	}
+	return jwkThumbprint(key)
+}
+
+// FindVerificationMethodByThumbprint returns the verification method whose public key has the given RFC 7638 JWK thumbprint (see JWKThumbprint),
+// including verification methods embedded in verification relationships. Verification methods whose key can't be decoded are skipped.
+// It returns nil if no verification method matches.
+func (d Document) FindVerificationMethodByThumbprint(thumbprint string) *VerificationMethod {
+	for _, vm := range d.allVerificationMethods() {
+		if actual, err := vm.JWKThumbprint(); err == nil && actual == thumbprint {
+			return vm
+		}
+	}
+	return nil
+}
+
+// FindVerificationMethodByKey returns the verification method that contains the given public key, regardless of its ID and encoding.
+// It returns nil if no verification method matches.
+func (d Document) FindVerificationMethodByKey(key crypto.PublicKey) (*VerificationMethod, error) {
+	thumbprint, err := JWKThumbprint(key)
+	if err != nil {
+		return nil, err
+	}
+	return d.FindVerificationMethodByThumbprint(thumbprint), nil
+}
+
+// allVerificationMethods returns the verification methods of the document and those embedded in verification relationships.
+func (d Document) allVerificationMethods() []*VerificationMethod {
+	var result []*VerificationMethod
+	d.forEachVerificationMethod(func(_ string, vm *VerificationMethod) {
+		result = append(result, vm)
+	})
+	return result
+}
+
+// forEachVerificationMethod calls fn for each verification method of the document and those embedded in verification relationships,
+// with the JSON pointer to the verification method.
+func (d Document) forEachVerificationMethod(fn func(pointer string, vm *VerificationMethod)) {
+	for i, vm := range d.VerificationMethod {
+		fn(ssi.JSONPointer(verificationMethodKey, i), vm)
+	}
+	for _, relationshipType := range relationshipTypes {
+		for i, relationship := range *d.relationship(relationshipType) {
+			if relationship.reference.Empty() && relationship.VerificationMethod != nil {
+				fn(ssi.JSONPointer(string(relationshipType), i), relationship.VerificationMethod)
+			}
+		}
+	}
+}
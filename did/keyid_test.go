@@ -0,0 +1,129 @@
+package did
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKThumbprint(t *testing.T) {
+	t.Run("RFC 7638 example", func(t *testing.T) {
+		// https://www.rfc-editor.org/rfc/rfc7638#section-3.1
+		key, err := jwk.ParseKey([]byte(`{
+			"kty": "RSA",
+			"n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+			"e": "AQAB"
+		}`))
+		require.NoError(t, err)
+		var publicKey rsa.PublicKey
+		require.NoError(t, jwk.Export(key, &publicKey))
+
+		thumbprint, err := JWKThumbprint(&publicKey)
+
+		require.NoError(t, err)
+		assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint)
+	})
+	t.Run("unsupported key", func(t *testing.T) {
+		_, err := JWKThumbprint("not a key")
+		assert.Error(t, err)
+	})
+}
+
+func TestMultibaseKey(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	vm, _ := NewVerificationMethod(MustParseDIDURL("did:example:123#1"), ssi.Multikey, MustParseDID("did:example:123"), publicKey)
+
+	actual, err := MultibaseKey(publicKey)
+
+	require.NoError(t, err)
+	assert.Equal(t, vm.PublicKeyMultibase, actual)
+}
+
+func TestNewVerificationMethod_Options(t *testing.T) {
+	id := MustParseDIDURL("did:example:123#ignored")
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	t.Run("WithJWKThumbprintFragment", func(t *testing.T) {
+		expected, _ := JWKThumbprint(&privateKey.PublicKey)
+
+		vm, err := NewVerificationMethod(id, ssi.JsonWebKey2020, id.DID, &privateKey.PublicKey, WithJWKThumbprintFragment())
+
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:123#"+expected, vm.ID.String())
+		// thumbprint is independent of the key encoding
+		vm, err = NewVerificationMethod(id, ssi.Multikey, id.DID, &privateKey.PublicKey, WithJWKThumbprintFragment())
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:123#"+expected, vm.ID.String())
+	})
+	t.Run("WithMultibaseFragment", func(t *testing.T) {
+		vm, err := NewVerificationMethod(id, ssi.Multikey, id.DID, &privateKey.PublicKey, WithMultibaseFragment())
+
+		require.NoError(t, err)
+		assert.Equal(t, vm.PublicKeyMultibase, vm.ID.Fragment)
+		// it also works for key types that don't use multibase
+		vm, err = NewVerificationMethod(id, ssi.JsonWebKey2020, id.DID, &privateKey.PublicKey, WithMultibaseFragment())
+		require.NoError(t, err)
+		assert.Equal(t, "zDn", vm.ID.Fragment[:3])
+	})
+	t.Run("ID survives JSON roundtrip", func(t *testing.T) {
+		vm, err := NewVerificationMethod(id, ssi.JsonWebKey2020, id.DID, &privateKey.PublicKey, WithJWKThumbprintFragment())
+		require.NoError(t, err)
+		data, _ := json.Marshal(vm)
+		var actual VerificationMethod
+		require.NoError(t, json.Unmarshal(data, &actual))
+		assert.Equal(t, vm.ID, actual.ID)
+	})
+	t.Run("key not supported", func(t *testing.T) {
+		rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		_, err := NewVerificationMethod(id, ssi.JsonWebKey2020, id.DID, &rsaKey.PublicKey, WithMultibaseFragment())
+		assert.ErrorContains(t, err, "unable to derive verification method ID: unsupported public key type")
+	})
+}
+
+func TestDocument_FindVerificationMethodByThumbprint(t *testing.T) {
+	docID := MustParseDID("did:example:123")
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	jwkVM, _ := NewVerificationMethod(MustParseDIDURL("did:example:123#key-1"), ssi.JsonWebKey2020, docID, &ecKey.PublicKey)
+	edVM, _ := NewVerificationMethod(MustParseDIDURL("did:example:123#key-2"), ssi.ED25519VerificationKey2020, docID, edKey)
+	document := Document{ID: docID}
+	document.AddAssertionMethod(jwkVM)
+	document.KeyAgreement = VerificationRelationships{{VerificationMethod: edVM}}
+
+	t.Run("by thumbprint", func(t *testing.T) {
+		thumbprint, _ := JWKThumbprint(&ecKey.PublicKey)
+		assert.Same(t, jwkVM, document.FindVerificationMethodByThumbprint(thumbprint))
+	})
+	t.Run("by key - embedded verification method", func(t *testing.T) {
+		actual, err := document.FindVerificationMethodByKey(edKey)
+		require.NoError(t, err)
+		assert.Same(t, edVM, actual)
+	})
+	t.Run("by key - encoded differently", func(t *testing.T) {
+		multikeyVM, _ := NewVerificationMethod(MustParseDIDURL("did:example:123#key-3"), ssi.Multikey, docID, &otherKey.PublicKey)
+		document := Document{ID: docID, VerificationMethod: VerificationMethods{multikeyVM}}
+
+		actual, err := document.FindVerificationMethodByKey(&otherKey.PublicKey)
+
+		require.NoError(t, err)
+		assert.Same(t, multikeyVM, actual)
+	})
+	t.Run("not found", func(t *testing.T) {
+		actual, err := document.FindVerificationMethodByKey(&otherKey.PublicKey)
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+	t.Run("verification method without key material", func(t *testing.T) {
+		_, err := VerificationMethod{Type: ssi.JsonWebKey2020}.JWKThumbprint()
+		assert.EqualError(t, err, "missing publicKeyJwk")
+	})
+}
@@ -0,0 +1,580 @@
+package did
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/multiformats/go-multibase"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/multicodec"
+	"github.com/shengdoushi/base58"
+)
+
+// KeyMaterialProperty is a property of a verification method that holds its public key.
+type KeyMaterialProperty string
+
+const (
+	// PublicKeyJwkProperty is the publicKeyJwk property (https://www.w3.org/TR/did-core/#dfn-publickeyjwk).
+	PublicKeyJwkProperty KeyMaterialProperty = "publicKeyJwk"
+	// PublicKeyMultibaseProperty is the publicKeyMultibase property (https://www.w3.org/TR/did-core/#dfn-publickeymultibase).
+	PublicKeyMultibaseProperty KeyMaterialProperty = "publicKeyMultibase"
+	// PublicKeyBase58Property is the deprecated publicKeyBase58 property (https://w3c.github.io/did-spec-registries/#publickeybase58).
+	PublicKeyBase58Property KeyMaterialProperty = "publicKeyBase58"
+	// PublicKeyPemProperty is the deprecated publicKeyPem property (https://w3c.github.io/did-spec-registries/#publickeypem).
+	PublicKeyPemProperty KeyMaterialProperty = "publicKeyPem"
+)
+
+// KeyEncoder sets the key material of the verification method from the given public key.
+type KeyEncoder func(key crypto.PublicKey, vm *VerificationMethod) error
+
+// KeyDecoder returns the public key described by the key material of the verification method.
+type KeyDecoder func(vm VerificationMethod) (crypto.PublicKey, error)
+
+// KeyChecker performs sanity checks on the key material of the verification method (e.g. key size or curve).
+type KeyChecker func(vm VerificationMethod) error
+
+// ErrPrivateKeyMaterial is returned when a verification method is created from, or contains, private key material.
+var ErrPrivateKeyMaterial = errors.New("verification method must not contain private key material")
+
+// privateJWKMembers contains the JWK parameters that hold private or secret key material (RFC 7518, section 6).
+var privateJWKMembers = []string{"d", "p", "q", "dp", "dq", "qi", "oth", "k"}
+
+// KeyTypeDefinition describes a verification method type: how its public key is encoded and which properties may hold it.
+type KeyTypeDefinition struct {
+	// Type is the verification method type, e.g. Multikey.
+	Type ssi.KeyType
+	// Context is the JSON-LD context that defines the type.
+	Context ssi.URI
+	// KeyMaterial lists the properties that may hold the public key.
+	KeyMaterial []KeyMaterialProperty
+	// Encoder is used by NewVerificationMethod to encode the public key.
+	Encoder KeyEncoder
+	// Decoder is used by VerificationMethod.PublicKey to decode the public key.
+	Decoder KeyDecoder
+	// Checker is used when creating or parsing a verification method to check its key material.
+	// Unlike Decoder, it shouldn't require the key to be supported by the platform, so that documents can be parsed without the optional crypto dependencies.
+	// It is optional.
+	Checker KeyChecker
+}
+
+var keyTypesMux = &sync.RWMutex{}
+var keyTypes = map[ssi.KeyType]KeyTypeDefinition{}
+
+// RegisterKeyType registers a verification method type, so it can be used by NewVerificationMethod and VerificationMethod.PublicKey.
+// Registering a type that is already registered replaces the existing definition, which can be used to override the built-in types.
+func RegisterKeyType(definition KeyTypeDefinition) error {
+	if definition.Type == "" {
+		return errors.New("key type definition: type is required")
+	}
+	if definition.Encoder == nil || definition.Decoder == nil {
+		return fmt.Errorf("key type definition %s: encoder and decoder are required", definition.Type)
+	}
+	if len(definition.KeyMaterial) == 0 {
+		return fmt.Errorf("key type definition %s: at least one key material property is required", definition.Type)
+	}
+	keyTypesMux.Lock()
+	defer keyTypesMux.Unlock()
+	keyTypes[definition.Type] = definition
+	return nil
+}
+
+// LookupKeyType returns the definition of the given verification method type, if it's registered.
+func LookupKeyType(keyType ssi.KeyType) (KeyTypeDefinition, bool) {
+	keyTypesMux.RLock()
+	defer keyTypesMux.RUnlock()
+	definition, ok := keyTypes[keyType]
+	return definition, ok
+}
+
+// RegisteredKeyTypes returns the registered verification method types, sorted by name.
+func RegisteredKeyTypes() []ssi.KeyType {
+	keyTypesMux.RLock()
+	defer keyTypesMux.RUnlock()
+	result := make([]ssi.KeyType, 0, len(keyTypes))
+	for keyType := range keyTypes {
+		result = append(result, keyType)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
+
+// checkKeyMaterial returns an error if the verification method holds key material in a property that isn't allowed for its type.
+func (d KeyTypeDefinition) checkKeyMaterial(vm VerificationMethod) error {
+	for _, property := range vm.keyMaterialProperties() {
+		allowed := false
+		for _, curr := range d.KeyMaterial {
+			if curr == property {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%s is not allowed for verification method type %s", property, d.Type)
+		}
+	}
+	return nil
+}
+
+// keyMaterialProperties returns the properties of the verification method that hold key material.
+func (v VerificationMethod) keyMaterialProperties() []KeyMaterialProperty {
+	var result []KeyMaterialProperty
+	if v.PublicKeyJwk != nil {
+		result = append(result, PublicKeyJwkProperty)
+	}
+	if v.PublicKeyMultibase != "" {
+		result = append(result, PublicKeyMultibaseProperty)
+	}
+	if v.PublicKeyBase58 != "" {
+		result = append(result, PublicKeyBase58Property)
+	}
+	if v.PublicKeyPem != "" {
+		result = append(result, PublicKeyPemProperty)
+	}
+	return result
+}
+
+// validateKeyMaterial checks the key material of the verification method:
+//   - publicKeyJwk must not contain private key material,
+//   - publicKeyMultibase and publicKeyBase58 must be decodable,
+//   - the Checker of the registered key type must pass (e.g. key size, curve), if the key material is in a property allowed for the type.
+//
+// Verification methods without key material pass.
+func (v VerificationMethod) validateKeyMaterial() error {
+	for _, member := range privateJWKMembers {
+		if _, ok := v.PublicKeyJwk[member]; ok {
+			return fmt.Errorf("%w: publicKeyJwk contains '%s'", ErrPrivateKeyMaterial, member)
+		}
+	}
+	if v.PublicKeyMultibase != "" {
+		if _, _, err := multibase.Decode(v.PublicKeyMultibase); err != nil {
+			return fmt.Errorf("publicKeyMultibase decode error: %w", err)
+		}
+	}
+	if v.PublicKeyBase58 != "" {
+		if _, err := base58.Decode(v.PublicKeyBase58, base58.BitcoinAlphabet); err != nil {
+			return fmt.Errorf("publicKeyBase58 decode error: %w", err)
+		}
+	}
+	if len(v.keyMaterialProperties()) == 0 {
+		return nil
+	}
+	definition, ok := LookupKeyType(v.Type)
+	if !ok || definition.Checker == nil || definition.checkKeyMaterial(v) != nil {
+		return nil
+	}
+	return definition.Checker(v)
+}
+
+// isPrivateKey returns whether the given key contains private key material.
+func isPrivateKey(key any) bool {
+	switch k := key.(type) {
+	case jwk.Key:
+		isPrivate, _ := jwk.IsPrivateKey(k)
+		return isPrivate || k.KeyType() == jwa.OctetSeq()
+	case []byte, ecdsa.PrivateKey, rsa.PrivateKey:
+		return true
+	case interface{ Public() crypto.PublicKey }:
+		// All private keys in the standard library (and secp256k1.PrivateKey) implement crypto.Signer or crypto.Decrypter
+		return true
+	}
+	return false
+}
+
+// checkDecoder returns a KeyChecker that tries to decode the key, for key types that can always be decoded.
+func checkDecoder(decoder KeyDecoder) KeyChecker {
+	return func(vm VerificationMethod) error {
+		_, err := decoder(vm)
+		return err
+	}
+}
+
+// checkJWK returns a KeyChecker that checks the key type and (if given) curve of publicKeyJwk.
+func checkJWK(kty string, curves ...string) KeyChecker {
+	return func(vm VerificationMethod) error {
+		if actual, _ := vm.PublicKeyJwk["kty"].(string); actual != kty {
+			return fmt.Errorf("publicKeyJwk: expected key type %s for %s, got '%s'", kty, vm.Type, actual)
+		}
+		if len(curves) == 0 {
+			return nil
+		}
+		actual, _ := vm.PublicKeyJwk["crv"].(string)
+		for _, curve := range curves {
+			if curve == actual {
+				return nil
+			}
+		}
+		return fmt.Errorf("publicKeyJwk: expected curve %s for %s, got '%s'", strings.Join(curves, " or "), vm.Type, actual)
+	}
+}
+
+func init() {
+	builtin := []KeyTypeDefinition{
+		{
+			Type:        ssi.JsonWebKey2020,
+			Context:     ssi.MustParseURI("https://w3id.org/security/suites/jws-2020/v1"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyJwkProperty},
+			Encoder:     encodeJWK,
+			Decoder:     decodeJWK,
+		},
+		{
+			Type:        ssi.JsonWebKey,
+			Context:     ssi.MustParseURI("https://w3id.org/security/jwk/v1"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyJwkProperty},
+			Encoder:     encodeJWK,
+			Decoder:     decodeJWK,
+		},
+		{
+			Type:        ssi.RSAVerificationKey2018,
+			Context:     ssi.MustParseURI("https://w3id.org/security/v2"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyJwkProperty, PublicKeyPemProperty},
+			Encoder:     encodeRSA,
+			Decoder:     decodeRSA,
+			Checker:     checkRSA,
+		},
+		{
+			Type:        ssi.ECDSASECP256R1VerificationKey2019,
+			Context:     ssi.MustParseURI("https://w3id.org/security/suites/ecdsa-2019/v1"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyMultibaseProperty},
+			Encoder:     encodeECDSASecp256r1,
+			Decoder:     decodeECDSASecp256r1,
+			Checker:     checkDecoder(decodeECDSASecp256r1),
+		},
+		{
+			Type:        ssi.ECDSASECP256K1VerificationKey2019,
+			Context:     ssi.MustParseURI("https://w3id.org/security/suites/secp256k1-2019/v1"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyJwkProperty},
+			Encoder:     encodeJWK,
+			Decoder:     decodeJWK,
+			Checker:     checkJWK("EC", "secp256k1"),
+		},
+		{
+			Type:        ssi.ED25519VerificationKey2018,
+			Context:     ssi.MustParseURI("https://w3id.org/security/suites/ed25519-2018/v1"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyMultibaseProperty, PublicKeyBase58Property},
+			Encoder:     encodeEd25519,
+			Decoder:     decodeEd25519,
+			Checker:     checkDecoder(decodeEd25519),
+		},
+		{
+			Type:        ssi.ED25519VerificationKey2020,
+			Context:     ssi.MustParseURI("https://w3id.org/security/suites/ed25519-2020/v1"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyMultibaseProperty, PublicKeyBase58Property},
+			Encoder:     encodeEd25519,
+			Decoder:     decodeEd25519,
+			Checker:     checkDecoder(decodeEd25519),
+		},
+		{
+			Type:        ssi.X25519KeyAgreementKey2019,
+			Context:     ssi.MustParseURI("https://w3id.org/security/suites/x25519-2019/v1"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyBase58Property, PublicKeyMultibaseProperty},
+			Encoder:     encodeX25519KeyAgreementKey2019,
+			Decoder:     decodeX25519KeyAgreementKey2019,
+			Checker:     checkDecoder(decodeX25519KeyAgreementKey2019),
+		},
+		{
+			Type:        ssi.X25519KeyAgreementKey2020,
+			Context:     ssi.MustParseURI("https://w3id.org/security/suites/x25519-2020/v1"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyMultibaseProperty},
+			Encoder:     encodeX25519KeyAgreementKey2020,
+			Decoder:     decodeX25519KeyAgreementKey2020,
+			Checker:     checkDecoder(decodeX25519KeyAgreementKey2020),
+		},
+		{
+			Type:        ssi.Multikey,
+			Context:     ssi.MustParseURI("https://w3id.org/security/multikey/v1"),
+			KeyMaterial: []KeyMaterialProperty{PublicKeyMultibaseProperty},
+			Encoder:     encodeMultikey,
+			Decoder:     decodeMultikey,
+			Checker:     checkDecoder(decodeMultikey),
+		},
+	}
+	for _, definition := range builtin {
+		if err := RegisterKeyType(definition); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func encodeJWK(key crypto.PublicKey, vm *VerificationMethod) error {
+	keyAsJWK, err := jwk.Import(key)
+	if err != nil {
+		return err
+	}
+	// Convert to JSON and back to fix encoding of key material to make sure
+	// an unmarshalled and newly created VerificationMethod are equal on object level.
+	// The format of PublicKeyJwk in verificationMethod is a map[string]interface{}.
+	// After unmarshalling all the fields will be map[string]string.
+	keyAsJSON, err := json.Marshal(keyAsJWK)
+	if err != nil {
+		return err
+	}
+	keyAsMap := map[string]interface{}{}
+	if err := json.Unmarshal(keyAsJSON, &keyAsMap); err != nil {
+		return err
+	}
+	vm.PublicKeyJwk = keyAsMap
+	return nil
+}
+
+func decodeJWK(vm VerificationMethod) (crypto.PublicKey, error) {
+	if vm.PublicKeyJwk == nil {
+		return nil, errors.New("missing publicKeyJwk")
+	}
+	keyAsJWK, err := vm.JWK()
+	if err != nil {
+		return nil, err
+	}
+	var pubKey any
+	if err = jwk.Export(keyAsJWK, &pubKey); err != nil {
+		return nil, err
+	}
+	return pubKey, nil
+}
+
+func encodeRSA(key crypto.PublicKey, vm *VerificationMethod) error {
+	switch key.(type) {
+	case *rsa.PublicKey, rsa.PublicKey:
+		return encodeJWK(key, vm)
+	}
+	return errors.New("wrong key type")
+}
+
+func checkRSA(vm VerificationMethod) error {
+	if vm.PublicKeyJwk != nil {
+		return checkJWK("RSA")(vm)
+	}
+	_, err := decodeRSA(vm)
+	return err
+}
+
+// parsePublicKeyPEM parses a public key in PKIX ("PUBLIC KEY") or PKCS #1 ("RSA PUBLIC KEY") PEM format, without enforcing a minimum key size.
+func parsePublicKeyPEM(input string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(input))
+	if block == nil {
+		return nil, errors.New("publicKeyPem decode error: no PEM block found")
+	}
+	var publicKey crypto.PublicKey
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("publicKeyPem decode error: unsupported PEM block type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("publicKeyPem decode error: %w", err)
+	}
+	return publicKey, nil
+}
+
+func decodeRSA(vm VerificationMethod) (crypto.PublicKey, error) {
+	var publicKey crypto.PublicKey
+	if vm.PublicKeyPem != "" {
+		var err error
+		publicKey, err = parsePublicKeyPEM(vm.PublicKeyPem)
+		if err != nil {
+			return nil, err
+		}
+		// Import as JWK to apply the same minimum modulus size as keys in publicKeyJwk
+		if _, err := jwk.Import(publicKey); err != nil {
+			return nil, fmt.Errorf("publicKeyPem decode error: %w", err)
+		}
+	} else {
+		var err error
+		publicKey, err = decodeJWK(vm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := publicKey.(*rsa.PublicKey); !ok {
+		return nil, fmt.Errorf("expected RSA public key, got %T", publicKey)
+	}
+	return publicKey, nil
+}
+
+func encodeECDSASecp256r1(key crypto.PublicKey, vm *VerificationMethod) error {
+	switch key.(type) {
+	case *ecdsa.PublicKey, ecdsa.PublicKey:
+	default:
+		return errors.New("wrong key type")
+	}
+	keyBytes, err := multicodec.EncodePublicKey(key)
+	if err != nil {
+		return err
+	}
+	if code, _, _ := multicodec.Decode(keyBytes); code != multicodec.P256Pub {
+		return errors.New("wrong key type")
+	}
+	encodedKey, err := multibase.Encode(multibase.Base58BTC, keyBytes)
+	if err != nil {
+		return err
+	}
+	vm.PublicKeyMultibase = encodedKey
+	return nil
+}
+
+func decodeECDSASecp256r1(vm VerificationMethod) (crypto.PublicKey, error) {
+	keyBytes, err := vm.multibaseKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	code, _, err := multicodec.Decode(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	if code != multicodec.P256Pub {
+		return nil, fmt.Errorf("expected %s multicodec, got %s", multicodec.P256Pub, code)
+	}
+	return multicodec.DecodePublicKey(keyBytes)
+}
+
+func encodeEd25519(key crypto.PublicKey, vm *VerificationMethod) error {
+	ed25519Key, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return errors.New("wrong key type")
+	}
+	encodedKey, err := multibase.Encode(multibase.Base58BTC, ed25519Key)
+	if err != nil {
+		return err
+	}
+	vm.PublicKeyMultibase = encodedKey
+	return nil
+}
+
+func decodeEd25519(vm VerificationMethod) (crypto.PublicKey, error) {
+	keyBytes, err := vm.encodedKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	// Some implementations encode the key with its multicodec prefix (as Multikey does), so accept that as well.
+	if len(keyBytes) != ed25519.PublicKeySize {
+		if code, unprefixed, err := multicodec.Decode(keyBytes); err == nil && code == multicodec.Ed25519Pub {
+			keyBytes = unprefixed
+		}
+	}
+	if len(keyBytes) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 public key size: %d", len(keyBytes))
+	}
+	return ed25519.PublicKey(keyBytes), nil
+}
+
+func encodeX25519KeyAgreementKey2019(key crypto.PublicKey, vm *VerificationMethod) error {
+	x25519Key, err := toX25519PublicKey(key)
+	if err != nil {
+		return err
+	}
+	vm.PublicKeyBase58 = base58.Encode(x25519Key.Bytes(), base58.BitcoinAlphabet)
+	return nil
+}
+
+func decodeX25519KeyAgreementKey2019(vm VerificationMethod) (crypto.PublicKey, error) {
+	keyBytes, err := vm.encodedKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := ecdh.X25519().NewPublicKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid X25519 public key: %w", err)
+	}
+	return publicKey, nil
+}
+
+func encodeX25519KeyAgreementKey2020(key crypto.PublicKey, vm *VerificationMethod) error {
+	x25519Key, err := toX25519PublicKey(key)
+	if err != nil {
+		return err
+	}
+	encodedKey, err := multibase.Encode(multibase.Base58BTC, multicodec.Encode(multicodec.X25519Pub, x25519Key.Bytes()))
+	if err != nil {
+		return err
+	}
+	vm.PublicKeyMultibase = encodedKey
+	return nil
+}
+
+func decodeX25519KeyAgreementKey2020(vm VerificationMethod) (crypto.PublicKey, error) {
+	keyBytes, err := vm.multibaseKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	code, keyBytes, err := multicodec.Decode(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	if code != multicodec.X25519Pub {
+		return nil, fmt.Errorf("expected %s multicodec, got %s", multicodec.X25519Pub, code)
+	}
+	publicKey, err := ecdh.X25519().NewPublicKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid X25519 public key: %w", err)
+	}
+	return publicKey, nil
+}
+
+func toX25519PublicKey(key crypto.PublicKey) (*ecdh.PublicKey, error) {
+	x25519Key, ok := key.(*ecdh.PublicKey)
+	if !ok || x25519Key.Curve() != ecdh.X25519() {
+		return nil, errors.New("wrong key type")
+	}
+	return x25519Key, nil
+}
+
+func encodeMultikey(key crypto.PublicKey, vm *VerificationMethod) error {
+	encodedKey, err := MultibaseKey(key)
+	if err != nil {
+		return err
+	}
+	vm.PublicKeyMultibase = encodedKey
+	return nil
+}
+
+func decodeMultikey(vm VerificationMethod) (crypto.PublicKey, error) {
+	keyBytes, err := vm.multibaseKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	return multicodec.DecodePublicKey(keyBytes)
+}
+
+// multibaseKeyBytes returns the decoded publicKeyMultibase property.
+func (v VerificationMethod) multibaseKeyBytes() ([]byte, error) {
+	if v.PublicKeyMultibase == "" {
+		return nil, errors.New("missing publicKeyMultibase")
+	}
+	_, keyBytes, err := multibase.Decode(v.PublicKeyMultibase)
+	if err != nil {
+		return nil, fmt.Errorf("publicKeyMultibase decode error: %w", err)
+	}
+	return keyBytes, nil
+}
+
+// encodedKeyBytes returns the decoded publicKeyMultibase or publicKeyBase58 property.
+func (v VerificationMethod) encodedKeyBytes() ([]byte, error) {
+	if v.PublicKeyMultibase != "" {
+		return v.multibaseKeyBytes()
+	} else if v.PublicKeyBase58 != "" {
+		keyBytes, err := base58.Decode(v.PublicKeyBase58, base58.BitcoinAlphabet)
+		if err != nil {
+			return nil, fmt.Errorf("publicKeyBase58 decode error: %w", err)
+		}
+		return keyBytes, nil
+	}
+	return nil, errors.New("expected either publicKeyMultibase or publicKeyBase58 to be set")
+}
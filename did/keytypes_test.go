@@ -0,0 +1,345 @@
+package did
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPQCPublicKey []byte
+
+func TestRegisterKeyType(t *testing.T) {
+	const keyType = ssi.KeyType("TestPQCVerificationKey2024")
+	definition := KeyTypeDefinition{
+		Type:        keyType,
+		Context:     ssi.MustParseURI("https://example.com/pqc/v1"),
+		KeyMaterial: []KeyMaterialProperty{PublicKeyMultibaseProperty},
+		Encoder: func(key crypto.PublicKey, vm *VerificationMethod) error {
+			pqcKey, ok := key.(testPQCPublicKey)
+			if !ok {
+				return errors.New("wrong key type")
+			}
+			vm.PublicKeyMultibase = "u" + string(pqcKey)
+			return nil
+		},
+		Decoder: func(vm VerificationMethod) (crypto.PublicKey, error) {
+			return testPQCPublicKey(vm.PublicKeyMultibase[1:]), nil
+		},
+	}
+	t.Cleanup(func() {
+		keyTypesMux.Lock()
+		defer keyTypesMux.Unlock()
+		delete(keyTypes, keyType)
+	})
+	id := MustParseDIDURL("did:example:123#key-1")
+
+	t.Run("unregistered type", func(t *testing.T) {
+		vm, err := NewVerificationMethod(id, keyType, id.DID, testPQCPublicKey("key"))
+		require.NoError(t, err)
+		assert.Empty(t, vm.PublicKeyMultibase)
+		_, err = vm.PublicKey()
+		assert.EqualError(t, err, "unsupported verification method type")
+	})
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, RegisterKeyType(definition))
+
+		vm, err := NewVerificationMethod(id, keyType, id.DID, testPQCPublicKey("key"))
+		require.NoError(t, err)
+		assert.Equal(t, "ukey", vm.PublicKeyMultibase)
+		publicKey, err := vm.PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, testPQCPublicKey("key"), publicKey)
+
+		actual, ok := LookupKeyType(keyType)
+		assert.True(t, ok)
+		assert.Equal(t, "https://example.com/pqc/v1", actual.Context.String())
+		assert.Contains(t, RegisteredKeyTypes(), keyType)
+	})
+	t.Run("key material in a property that isn't allowed", func(t *testing.T) {
+		require.NoError(t, RegisterKeyType(definition))
+		vm := VerificationMethod{Type: keyType, PublicKeyBase58: "abc"}
+
+		_, err := vm.PublicKey()
+
+		assert.EqualError(t, err, "publicKeyBase58 is not allowed for verification method type TestPQCVerificationKey2024")
+	})
+	t.Run("override built-in type", func(t *testing.T) {
+		original, _ := LookupKeyType(ssi.ED25519VerificationKey2020)
+		t.Cleanup(func() {
+			_ = RegisterKeyType(original)
+		})
+		override := original
+		override.Encoder = func(key crypto.PublicKey, vm *VerificationMethod) error {
+			return errors.New("overridden")
+		}
+		require.NoError(t, RegisterKeyType(override))
+		publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+
+		_, err := NewVerificationMethod(id, ssi.ED25519VerificationKey2020, id.DID, publicKey)
+
+		assert.EqualError(t, err, "overridden")
+	})
+	t.Run("invalid definitions", func(t *testing.T) {
+		assert.EqualError(t, RegisterKeyType(KeyTypeDefinition{}), "key type definition: type is required")
+		invalid := definition
+		invalid.Decoder = nil
+		assert.EqualError(t, RegisterKeyType(invalid), "key type definition TestPQCVerificationKey2024: encoder and decoder are required")
+		invalid = definition
+		invalid.KeyMaterial = nil
+		assert.EqualError(t, RegisterKeyType(invalid), "key type definition TestPQCVerificationKey2024: at least one key material property is required")
+	})
+}
+
+func TestRegisteredKeyTypes(t *testing.T) {
+	assert.Equal(t, []ssi.KeyType{
+		ssi.ECDSASECP256K1VerificationKey2019,
+		ssi.ECDSASECP256R1VerificationKey2019,
+		ssi.ED25519VerificationKey2018,
+		ssi.ED25519VerificationKey2020,
+		ssi.JsonWebKey,
+		ssi.JsonWebKey2020,
+		ssi.Multikey,
+		ssi.RSAVerificationKey2018,
+		ssi.X25519KeyAgreementKey2019,
+		ssi.X25519KeyAgreementKey2020,
+	}, RegisteredKeyTypes())
+}
+
+func TestVerificationMethod_PublicKey_KeyMaterial(t *testing.T) {
+	t.Run("JsonWebKey2020 with publicKeyMultibase", func(t *testing.T) {
+		vm := VerificationMethod{Type: ssi.JsonWebKey2020, PublicKeyMultibase: "z6MkmM42vxfqZQsv4ehtTjFFxQ4sQKS2w6WR7emozFAn5cxu"}
+		_, err := vm.PublicKey()
+		assert.EqualError(t, err, "publicKeyMultibase is not allowed for verification method type JsonWebKey2020")
+	})
+	t.Run("JsonWebKey2020 without key material", func(t *testing.T) {
+		_, err := VerificationMethod{Type: ssi.JsonWebKey2020}.PublicKey()
+		assert.EqualError(t, err, "missing publicKeyJwk")
+	})
+}
+
+func TestVerificationMethod_RSAVerificationKey2018(t *testing.T) {
+	id := MustParseDIDURL("did:example:123#key-1")
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	t.Run("publicKeyJwk", func(t *testing.T) {
+		vm, err := NewVerificationMethod(id, ssi.RSAVerificationKey2018, id.DID, &privateKey.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, "RSA", vm.PublicKeyJwk["kty"])
+
+		publicKey, err := vm.PublicKey()
+
+		require.NoError(t, err)
+		assert.Equal(t, &privateKey.PublicKey, publicKey)
+	})
+	t.Run("publicKeyPem (PKIX)", func(t *testing.T) {
+		keyBytes, _ := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		vm := VerificationMethod{
+			Type:         ssi.RSAVerificationKey2018,
+			PublicKeyPem: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: keyBytes})),
+		}
+
+		publicKey, err := vm.PublicKey()
+
+		require.NoError(t, err)
+		assert.Equal(t, &privateKey.PublicKey, publicKey)
+	})
+	t.Run("publicKeyPem (PKCS#1)", func(t *testing.T) {
+		keyBytes := x509.MarshalPKCS1PublicKey(&privateKey.PublicKey)
+		vm := VerificationMethod{
+			Type:         ssi.RSAVerificationKey2018,
+			PublicKeyPem: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: keyBytes})),
+		}
+
+		publicKey, err := vm.PublicKey()
+
+		require.NoError(t, err)
+		assert.Equal(t, &privateKey.PublicKey, publicKey)
+	})
+	t.Run("publicKeyPem from JSON", func(t *testing.T) {
+		keyBytes, _ := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		input, _ := json.Marshal(map[string]interface{}{
+			"id":           id.String(),
+			"type":         "RsaVerificationKey2018",
+			"controller":   id.DID.String(),
+			"publicKeyPem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: keyBytes})),
+		})
+		var vm VerificationMethod
+		require.NoError(t, json.Unmarshal(input, &vm))
+
+		publicKey, err := vm.PublicKey()
+
+		require.NoError(t, err)
+		assert.Equal(t, &privateKey.PublicKey, publicKey)
+	})
+	t.Run("publicKeyPem - not PEM", func(t *testing.T) {
+		_, err := VerificationMethod{Type: ssi.RSAVerificationKey2018, PublicKeyPem: "foo"}.PublicKey()
+		assert.EqualError(t, err, "publicKeyPem decode error: no PEM block found")
+	})
+	t.Run("publicKeyPem - unsupported block type", func(t *testing.T) {
+		vm := VerificationMethod{
+			Type:         ssi.RSAVerificationKey2018,
+			PublicKeyPem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}})),
+		}
+		_, err := vm.PublicKey()
+		assert.EqualError(t, err, "publicKeyPem decode error: unsupported PEM block type: CERTIFICATE")
+	})
+	t.Run("publicKeyPem - not an RSA key", func(t *testing.T) {
+		ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		keyBytes, _ := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+		vm := VerificationMethod{
+			Type:         ssi.RSAVerificationKey2018,
+			PublicKeyPem: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: keyBytes})),
+		}
+		_, err := vm.PublicKey()
+		assert.EqualError(t, err, "expected RSA public key, got *ecdsa.PublicKey")
+	})
+	t.Run("wrong key type", func(t *testing.T) {
+		ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		_, err := NewVerificationMethod(id, ssi.RSAVerificationKey2018, id.DID, &ecKey.PublicKey)
+		assert.EqualError(t, err, "wrong key type")
+	})
+}
+
+func TestVerificationMethod_ECDSASECP256R1VerificationKey2019(t *testing.T) {
+	id := MustParseDIDURL("did:example:123#key-1")
+	t.Run("ok", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		vm, err := NewVerificationMethod(id, ssi.ECDSASECP256R1VerificationKey2019, id.DID, &privateKey.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, "zDn", vm.PublicKeyMultibase[:3])
+
+		publicKey, err := vm.PublicKey()
+
+		require.NoError(t, err)
+		assert.Equal(t, &privateKey.PublicKey, publicKey)
+	})
+	t.Run("wrong curve", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		_, err := NewVerificationMethod(id, ssi.ECDSASECP256R1VerificationKey2019, id.DID, &privateKey.PublicKey)
+		assert.EqualError(t, err, "wrong key type")
+	})
+	t.Run("wrong multicodec", func(t *testing.T) {
+		vm := VerificationMethod{
+			Type:               ssi.ECDSASECP256R1VerificationKey2019,
+			PublicKeyMultibase: "z6MkmM42vxfqZQsv4ehtTjFFxQ4sQKS2w6WR7emozFAn5cxu",
+		}
+		_, err := vm.PublicKey()
+		assert.EqualError(t, err, "expected p256-pub multicodec, got ed25519-pub")
+	})
+}
+
+func TestVerificationMethod_JsonWebKey(t *testing.T) {
+	id := MustParseDIDURL("did:example:123#key-1")
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	vm, err := NewVerificationMethod(id, ssi.JsonWebKey, id.DID, &privateKey.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, "EC", vm.PublicKeyJwk["kty"])
+
+	publicKey, err := vm.PublicKey()
+
+	require.NoError(t, err)
+	assert.Equal(t, &privateKey.PublicKey, publicKey)
+}
+
+func TestNewVerificationMethod_KeyMaterial(t *testing.T) {
+	id := MustParseDIDURL("did:example:123#key-1")
+	t.Run("private keys are rejected", func(t *testing.T) {
+		ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+		xKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+		jwkKey, _ := jwk.Import(ecKey)
+		symmetricKey, _ := jwk.Import([]byte("secret"))
+		testCases := map[string]any{
+			"*ecdsa.PrivateKey":  ecKey,
+			"ecdsa.PrivateKey":   *ecKey,
+			"*rsa.PrivateKey":    rsaKey,
+			"rsa.PrivateKey":     *rsaKey,
+			"ed25519.PrivateKey": edKey,
+			"*ecdh.PrivateKey":   xKey,
+			"private jwk.Key":    jwkKey,
+			"symmetric jwk.Key":  symmetricKey,
+			"[]byte":             []byte("secret"),
+		}
+		for name, key := range testCases {
+			t.Run(name, func(t *testing.T) {
+				_, err := NewVerificationMethod(id, ssi.JsonWebKey2020, id.DID, key)
+				assert.ErrorIs(t, err, ErrPrivateKeyMaterial)
+			})
+		}
+	})
+	t.Run("Ed25519 key with invalid length", func(t *testing.T) {
+		_, err := NewVerificationMethod(id, ssi.ED25519VerificationKey2018, id.DID, ed25519.PublicKey([]byte{1, 2, 3}))
+		assert.EqualError(t, err, "invalid Ed25519 public key size: 3")
+	})
+	t.Run("JWK curve doesn't match type", func(t *testing.T) {
+		ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		_, err := NewVerificationMethod(id, ssi.ECDSASECP256K1VerificationKey2019, id.DID, &ecKey.PublicKey)
+		assert.EqualError(t, err, "publicKeyJwk: expected curve secp256k1 for EcdsaSecp256k1VerificationKey2019, got 'P-256'")
+	})
+}
+
+func TestVerificationMethod_UnmarshalJSON_KeyMaterial(t *testing.T) {
+	unmarshal := func(vm map[string]interface{}) error {
+		vm["id"] = "did:example:123#key-1"
+		vm["controller"] = "did:example:123"
+		input, _ := json.Marshal(vm)
+		var result VerificationMethod
+		return json.Unmarshal(input, &result)
+	}
+	t.Run("private key in publicKeyJwk", func(t *testing.T) {
+		err := unmarshal(map[string]interface{}{
+			"type":         "JsonWebKey2020",
+			"publicKeyJwk": map[string]interface{}{"kty": "OKP", "crv": "Ed25519", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo", "d": "nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A"},
+		})
+		assert.ErrorIs(t, err, ErrPrivateKeyMaterial)
+		assert.EqualError(t, err, "invalid key material of verification method did:example:123#key-1: verification method must not contain private key material: publicKeyJwk contains 'd'")
+	})
+	t.Run("symmetric key in publicKeyJwk", func(t *testing.T) {
+		err := unmarshal(map[string]interface{}{
+			"type":         "JsonWebKey2020",
+			"publicKeyJwk": map[string]interface{}{"kty": "oct", "k": "c2VjcmV0"},
+		})
+		assert.ErrorIs(t, err, ErrPrivateKeyMaterial)
+	})
+	t.Run("Ed25519 key with invalid length", func(t *testing.T) {
+		err := unmarshal(map[string]interface{}{
+			"type":            "Ed25519VerificationKey2018",
+			"publicKeyBase58": "abc",
+		})
+		assert.ErrorContains(t, err, "invalid Ed25519 public key size")
+	})
+	t.Run("JWK curve doesn't match type", func(t *testing.T) {
+		err := unmarshal(map[string]interface{}{
+			"type":         "EcdsaSecp256k1VerificationKey2019",
+			"publicKeyJwk": map[string]interface{}{"kty": "EC", "crv": "P-256", "x": "abc", "y": "def"},
+		})
+		assert.ErrorContains(t, err, "expected curve secp256k1 for EcdsaSecp256k1VerificationKey2019, got 'P-256'")
+	})
+	t.Run("undecodable multibase", func(t *testing.T) {
+		err := unmarshal(map[string]interface{}{
+			"type":               "SomeUnknownType",
+			"publicKeyMultibase": "!invalid",
+		})
+		assert.ErrorContains(t, err, "publicKeyMultibase decode error")
+	})
+	t.Run("no key material", func(t *testing.T) {
+		err := unmarshal(map[string]interface{}{
+			"type": "Ed25519VerificationKey2018",
+		})
+		assert.NoError(t, err)
+	})
+}
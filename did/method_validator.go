@@ -0,0 +1,173 @@
+package did
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/multiformats/go-multibase"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/multicodec"
+)
+
+var methodValidatorsMux = &sync.RWMutex{}
+var methodValidators = map[string]Validator{
+	"web": DIDWebValidator{},
+	"key": DIDKeyValidator{},
+}
+
+// RegisterMethodValidator registers the validator for DID documents of the given DID method (e.g. "web"), which is run by MethodValidator.
+// Registering a validator for a method that already has one replaces it. Validators for did:web and did:key are registered by default.
+func RegisterMethodValidator(method string, validator Validator) {
+	methodValidatorsMux.Lock()
+	defer methodValidatorsMux.Unlock()
+	methodValidators[method] = validator
+}
+
+// LookupMethodValidator returns the validator registered for the given DID method, if any.
+func LookupMethodValidator(method string) (Validator, bool) {
+	methodValidatorsMux.RLock()
+	defer methodValidatorsMux.RUnlock()
+	validator, ok := methodValidators[method]
+	return validator, ok
+}
+
+// MethodValidator validates a DID document using W3CSpecValidator and the validator registered for the DID method of the document's ID
+// (see RegisterMethodValidator). If no validator is registered for the method, only W3CSpecValidator is applied.
+type MethodValidator struct{}
+
+func (m MethodValidator) Validate(document Document) error {
+	validator, ok := LookupMethodValidator(document.ID.Method)
+	if !ok {
+		return W3CSpecValidator{}.Validate(document)
+	}
+	return MultiValidator{Validators: []Validator{W3CSpecValidator{}, validator}}.Validate(document)
+}
+
+// Report validates the DID document and returns all findings.
+func (m MethodValidator) Report(document Document) ssi.ValidationReport {
+	validators := []Validator{W3CSpecValidator{}}
+	if validator, ok := LookupMethodValidator(document.ID.Method); ok {
+		validators = append(validators, validator)
+	}
+	return MultiValidator{Validators: validators}.Report(document)
+}
+
+// DIDWebValidator validates the rules of the did:web method (https://w3c-ccg.github.io/did-method-web/):
+// the method-specific ID must consist of a valid domain name (not an IP address), optionally followed by a percent-encoded port and path segments.
+type DIDWebValidator struct{}
+
+func (w DIDWebValidator) Validate(document Document) error {
+	return firstError(w.Report(document))
+}
+
+// Report validates the DID document and returns all findings.
+func (w DIDWebValidator) Report(document Document) ssi.ValidationReport {
+	return runReporters(document, w)
+}
+
+func (w DIDWebValidator) report(document Document, report *ssi.ValidationReport) {
+	if document.ID.Method != "web" {
+		report.AddError(ssi.JSONPointer("id"), ErrInvalidID, "did:web: not a did:web DID: "+document.ID.String())
+		return
+	}
+	segments := strings.Split(document.ID.ID, ":")
+	host, err := url.PathUnescape(segments[0])
+	if err != nil {
+		report.AddError(ssi.JSONPointer("id"), ErrInvalidID, "did:web: invalid percent-encoding in domain: "+segments[0])
+		return
+	}
+	if message := validateWebHost(host); message != "" {
+		report.AddError(ssi.JSONPointer("id"), ErrInvalidID, "did:web: "+message)
+	}
+	for _, segment := range segments[1:] {
+		if segment == "" {
+			report.AddError(ssi.JSONPointer("id"), ErrInvalidID, "did:web: path contains an empty segment")
+			break
+		}
+	}
+}
+
+// validateWebHost validates the (decoded) host and optional port of a did:web DID, returning a description of the problem if it's invalid.
+func validateWebHost(host string) string {
+	hostname := host
+	if index := strings.LastIndex(host, ":"); index != -1 {
+		hostname = host[:index]
+		port, err := strconv.Atoi(host[index+1:])
+		if err != nil || port < 1 || port > 65535 {
+			return "invalid port: " + host[index+1:]
+		}
+	}
+	if net.ParseIP(strings.Trim(hostname, "[]")) != nil {
+		return "domain must not be an IP address: " + hostname
+	}
+	if len(hostname) == 0 || len(hostname) > 253 {
+		return "invalid domain name: " + hostname
+	}
+	for _, label := range strings.Split(hostname, ".") {
+		if !isValidDomainLabel(label) {
+			return "invalid domain name: " + hostname
+		}
+	}
+	return ""
+}
+
+// isValidDomainLabel checks whether the label is a valid DNS label (RFC 1123): 1-63 alphanumeric characters or hyphens, not starting or ending with a hyphen.
+func isValidDomainLabel(label string) bool {
+	if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// DIDKeyValidator validates the rules of the did:key method (https://w3c-ccg.github.io/did-method-key/):
+// the method-specific ID must be a base58btc multibase encoded, multicodec-prefixed public key,
+// and the document must contain exactly one verification method, which contains that public key.
+// Verification methods embedded in verification relationships (e.g. a derived X25519 key agreement key) are not counted.
+type DIDKeyValidator struct{}
+
+func (k DIDKeyValidator) Validate(document Document) error {
+	return firstError(k.Report(document))
+}
+
+// Report validates the DID document and returns all findings.
+func (k DIDKeyValidator) Report(document Document) ssi.ValidationReport {
+	return runReporters(document, k)
+}
+
+func (k DIDKeyValidator) report(document Document, report *ssi.ValidationReport) {
+	if document.ID.Method != "key" {
+		report.AddError(ssi.JSONPointer("id"), ErrInvalidID, "did:key: not a did:key DID: "+document.ID.String())
+		return
+	}
+	encoding, keyBytes, err := multibase.Decode(document.ID.ID)
+	if err != nil || encoding != multibase.Base58BTC {
+		report.AddError(ssi.JSONPointer("id"), ErrInvalidID, "did:key: method-specific ID is not a base58btc multibase value: "+document.ID.ID)
+		return
+	}
+	expectedKey, err := multicodec.DecodePublicKey(keyBytes)
+	if err != nil {
+		report.AddError(ssi.JSONPointer("id"), ErrInvalidID, "did:key: method-specific ID does not contain a supported public key: "+err.Error())
+		return
+	}
+	if len(document.VerificationMethod) != 1 {
+		report.AddError(ssi.JSONPointer(verificationMethodKey), ErrInvalidVerificationMethod, "did:key: document must contain exactly one verification method, found "+strconv.Itoa(len(document.VerificationMethod)))
+		return
+	}
+	expected, _ := MultibaseKey(expectedKey)
+	publicKey, err := document.VerificationMethod[0].PublicKey()
+	if err != nil {
+		report.AddError(ssi.JSONPointer(verificationMethodKey, 0), ErrInvalidVerificationMethod, "did:key: unable to decode public key: "+err.Error())
+		return
+	}
+	if actual, err := MultibaseKey(publicKey); err != nil || actual != expected {
+		report.AddError(ssi.JSONPointer(verificationMethodKey, 0), ErrInvalidVerificationMethod, "did:key: verification method does not contain the key of the DID")
+	}
+}
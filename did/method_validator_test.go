@@ -0,0 +1,196 @@
+package did
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodValidator(t *testing.T) {
+	t.Run("method without validator", func(t *testing.T) {
+		assert.NoError(t, MethodValidator{}.Validate(document()))
+		assert.Empty(t, MethodValidator{}.Report(document()).Findings)
+	})
+	t.Run("W3CSpecValidator is applied", func(t *testing.T) {
+		input := document()
+		input.Context = nil
+
+		assertIsError(t, ErrInvalidContext, MethodValidator{}.Validate(input))
+	})
+	t.Run("registered validator is applied", func(t *testing.T) {
+		expected := errors.New("custom")
+		RegisterMethodValidator("test", errorValidator{err: expected})
+		defer func() {
+			methodValidatorsMux.Lock()
+			delete(methodValidators, "test")
+			methodValidatorsMux.Unlock()
+		}()
+
+		assert.ErrorIs(t, MethodValidator{}.Validate(document()), expected)
+		report := MethodValidator{}.Report(document())
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, "error at /: custom", report.Findings[0].String())
+	})
+	t.Run("did:web", func(t *testing.T) {
+		input := document()
+		input.ID = MustParseDID("did:web:example.com%3A8443:user:alice")
+		input.Controller = nil
+		for _, vm := range input.VerificationMethod {
+			vm.ID.DID = input.ID
+			vm.Controller = input.ID
+		}
+		input.Service = nil
+
+		assert.NoError(t, MethodValidator{}.Validate(input))
+	})
+}
+
+func TestLookupMethodValidator(t *testing.T) {
+	validator, ok := LookupMethodValidator("web")
+	assert.True(t, ok)
+	assert.IsType(t, DIDWebValidator{}, validator)
+	_, ok = LookupMethodValidator("example")
+	assert.False(t, ok)
+}
+
+func TestDIDWebValidator(t *testing.T) {
+	reportFor := func(t *testing.T, id string) []string {
+		var result []string
+		for _, finding := range (DIDWebValidator{}).Report(Document{ID: MustParseDID(id)}).Findings {
+			result = append(result, finding.String())
+		}
+		return result
+	}
+	t.Run("ok", func(t *testing.T) {
+		for _, id := range []string{
+			"did:web:example.com",
+			"did:web:sub.example-domain.com",
+			"did:web:localhost%3A8080",
+			"did:web:example.com:user:alice",
+		} {
+			t.Run(id, func(t *testing.T) {
+				assert.Empty(t, reportFor(t, id))
+				assert.NoError(t, DIDWebValidator{}.Validate(Document{ID: MustParseDID(id)}))
+			})
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		testCases := map[string]string{
+			"did:example:123":             "error at /id: did:web: not a did:web DID: did:example:123",
+			"did:web:127.0.0.1":           "error at /id: did:web: domain must not be an IP address: 127.0.0.1",
+			"did:web:%5B%3A%3A1%5D%3A443": "error at /id: did:web: domain must not be an IP address: [::1]",
+			"did:web:example.com%3A0":     "error at /id: did:web: invalid port: 0",
+			"did:web:example.com%3Aabc":   "error at /id: did:web: invalid port: abc",
+			"did:web:-example.com":        "error at /id: did:web: invalid domain name: -example.com",
+			"did:web:example..com":        "error at /id: did:web: invalid domain name: example..com",
+			"did:web:example.com::alice":  "error at /id: did:web: path contains an empty segment",
+		}
+		for id, expected := range testCases {
+			t.Run(id, func(t *testing.T) {
+				assert.Equal(t, []string{expected}, reportFor(t, id))
+				assertIsError(t, ErrInvalidID, DIDWebValidator{}.Validate(Document{ID: MustParseDID(id)}))
+			})
+		}
+	})
+	t.Run("invalid percent-encoding", func(t *testing.T) {
+		report := DIDWebValidator{}.Report(Document{ID: DID{Method: "web", ID: "example.com%ZZ"}})
+
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, "error at /id: did:web: invalid percent-encoding in domain: example.com%ZZ", report.Findings[0].String())
+	})
+}
+
+func TestDIDKeyValidator(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	multibaseKey, err := MultibaseKey(publicKey)
+	require.NoError(t, err)
+	keyDocument := func(t *testing.T, keyType ssi.KeyType, key interface{}) Document {
+		id := MustParseDID("did:key:" + multibaseKey)
+		vm, err := NewVerificationMethod(MustParseDIDURL(id.String()+"#"+multibaseKey), keyType, id, key)
+		require.NoError(t, err)
+		result := Document{Context: []interface{}{DIDContextV1URI()}, ID: id}
+		result.AddAssertionMethod(vm)
+		return result
+	}
+	findings := func(report ssi.ValidationReport) []string {
+		var result []string
+		for _, finding := range report.Findings {
+			result = append(result, finding.String())
+		}
+		return result
+	}
+	t.Run("ok", func(t *testing.T) {
+		for _, keyType := range []ssi.KeyType{ssi.ED25519VerificationKey2020, ssi.Multikey, ssi.JsonWebKey2020} {
+			t.Run(string(keyType), func(t *testing.T) {
+				input := keyDocument(t, keyType, publicKey)
+
+				assert.NoError(t, DIDKeyValidator{}.Validate(input))
+				assert.NoError(t, MethodValidator{}.Validate(input))
+			})
+		}
+	})
+	t.Run("ok - embedded key agreement method", func(t *testing.T) {
+		input := keyDocument(t, ssi.ED25519VerificationKey2020, publicKey)
+		keyAgreement, err := NewX25519KeyAgreementMethod(MustParseDIDURL(input.ID.String()+"#x25519"), *input.VerificationMethod[0])
+		require.NoError(t, err)
+		input.KeyAgreement = VerificationRelationships{{VerificationMethod: keyAgreement}}
+
+		assert.NoError(t, DIDKeyValidator{}.Validate(input))
+	})
+	t.Run("not a did:key DID", func(t *testing.T) {
+		report := DIDKeyValidator{}.Report(Document{ID: MustParseDID("did:example:123")})
+
+		assert.Equal(t, []string{"error at /id: did:key: not a did:key DID: did:example:123"}, findings(report))
+	})
+	t.Run("method-specific ID is not multibase", func(t *testing.T) {
+		report := DIDKeyValidator{}.Report(Document{ID: MustParseDID("did:key:123")})
+
+		assert.Equal(t, []string{"error at /id: did:key: method-specific ID is not a base58btc multibase value: 123"}, findings(report))
+	})
+	t.Run("method-specific ID is not a public key", func(t *testing.T) {
+		report := DIDKeyValidator{}.Report(Document{ID: MustParseDID("did:key:z2J9gaYxrKVpdoG9A4gRnmpnRCcxU6agDtFVVBVdn1JedouoZN7SzcyREXXzWgt3gGiwpoHq7K68X4m32D8HgzG8wv3sY5j7")})
+
+		require.Len(t, report.Findings, 1)
+		assert.Contains(t, report.Findings[0].String(), "error at /id: did:key: method-specific ID does not contain a supported public key")
+	})
+	t.Run("document must contain exactly one verification method", func(t *testing.T) {
+		input := keyDocument(t, ssi.ED25519VerificationKey2020, publicKey)
+		input.AddAuthenticationMethod(&VerificationMethod{ID: MustParseDIDURL(input.ID.String() + "#other"), Type: ssi.ED25519VerificationKey2020, Controller: input.ID})
+
+		report := DIDKeyValidator{}.Report(input)
+
+		assert.Equal(t, []string{"error at /verificationMethod: did:key: document must contain exactly one verification method, found 2"}, findings(report))
+		assertIsError(t, ErrInvalidVerificationMethod, DIDKeyValidator{}.Validate(input))
+	})
+	t.Run("verification method contains other key", func(t *testing.T) {
+		otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		input := keyDocument(t, ssi.JsonWebKey2020, otherKey.Public())
+
+		report := DIDKeyValidator{}.Report(input)
+
+		assert.Equal(t, []string{"error at /verificationMethod/0: did:key: verification method does not contain the key of the DID"}, findings(report))
+	})
+	t.Run("verification method key can't be decoded", func(t *testing.T) {
+		input := keyDocument(t, ssi.ED25519VerificationKey2020, publicKey)
+		input.VerificationMethod[0].Type = "unknown"
+
+		report := DIDKeyValidator{}.Report(input)
+
+		assert.Equal(t, []string{"error at /verificationMethod/0: did:key: unable to decode public key: unsupported verification method type"}, findings(report))
+	})
+}
+
+type errorValidator struct {
+	err error
+}
+
+func (e errorValidator) Validate(_ Document) error {
+	return e.err
+}
@@ -0,0 +1,144 @@
+package did
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Normalizer normalizes the method-specific ID of a DID of a specific DID method, e.g. by lowercasing the parts that are case-insensitive.
+// It is given the ID in escaped form, after generic normalization of percent-encodings (see DID.Normalize), and must return it in escaped form.
+type Normalizer func(id string) string
+
+var normalizersMux = &sync.RWMutex{}
+var normalizers = map[string]Normalizer{
+	"web": normalizeWebID,
+	"pkh": normalizePKHID,
+}
+
+// RegisterNormalizer registers the Normalizer for DIDs of the given DID method (e.g. "web"), replacing the existing one (if any).
+// Normalizers for did:web and did:pkh are registered by default.
+func RegisterNormalizer(method string, normalizer Normalizer) {
+	normalizersMux.Lock()
+	defer normalizersMux.Unlock()
+	normalizers[method] = normalizer
+}
+
+func lookupNormalizer(method string) Normalizer {
+	normalizersMux.RLock()
+	defer normalizersMux.RUnlock()
+	return normalizers[method]
+}
+
+// Normalize returns the normalized form of the DID, which can be used to compare DIDs that are equivalent but differ in notation.
+// Percent-encodings in the method-specific ID are normalized according to RFC 3986 (section 6.2.2):
+// hexadecimal digits are uppercased, and percent-encoded characters that are allowed unencoded in a DID (idchar) are decoded (e.g. "%2d" becomes "-").
+// Then the Normalizer registered for the DID method (see RegisterNormalizer) is applied.
+func (d DID) Normalize() DID {
+	if d.Empty() {
+		return d
+	}
+	d.ID = normalizePercentEncoding(d.ID, isIDChar)
+	if normalizer := lookupNormalizer(d.Method); normalizer != nil {
+		// normalize percent-encodings again, in case the normalizer changed their case
+		d.ID = normalizePercentEncoding(normalizer(d.ID), isIDChar)
+	}
+	if decoded, err := url.PathUnescape(d.ID); err == nil {
+		d.DecodedID = decoded
+	}
+	return d
+}
+
+// EqualsNormalized checks whether the DID is equivalent to the other DID, by comparing their normalized forms (see Normalize).
+func (d DID) EqualsNormalized(other DID) bool {
+	return d.Normalize().Equals(other.Normalize())
+}
+
+// Normalize returns the normalized form of the DID URL: the DID is normalized (see DID.Normalize),
+// and percent-encodings in the path and fragment are normalized according to RFC 3986 (section 6.2.2).
+func (d DIDURL) Normalize() DIDURL {
+	d.DID = d.DID.Normalize()
+	d.Path = normalizePercentEncoding(d.Path, isUnreserved)
+	if decoded, err := url.PathUnescape(d.Path); err == nil {
+		d.DecodedPath = decoded
+	}
+	d.Fragment = normalizePercentEncoding(d.Fragment, isUnreserved)
+	if decoded, err := url.PathUnescape(d.Fragment); err == nil {
+		d.DecodedFragment = decoded
+	}
+	return d.cleanup()
+}
+
+// EqualsNormalized checks whether the DID URL is equivalent to the other DID URL, by comparing their normalized forms (see Normalize).
+func (d DIDURL) EqualsNormalized(other DIDURL) bool {
+	return d.Normalize().Equals(other.Normalize())
+}
+
+// normalizePercentEncoding uppercases the hexadecimal digits of percent-encodings, and decodes percent-encoded characters for which decode returns true.
+// Invalid percent-encodings are left as-is.
+func normalizePercentEncoding(input string, decode func(c byte) bool) string {
+	if !strings.Contains(input, "%") {
+		return input
+	}
+	var result strings.Builder
+	for i := 0; i < len(input); i++ {
+		if input[i] != '%' || i+2 >= len(input) || !isHex(input[i+1]) || !isHex(input[i+2]) {
+			result.WriteByte(input[i])
+			continue
+		}
+		c := unhex(input[i+1])<<4 | unhex(input[i+2])
+		if decode(c) {
+			result.WriteByte(c)
+		} else {
+			result.WriteString(strings.ToUpper(input[i : i+3]))
+		}
+		i += 2
+	}
+	return result.String()
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+// isIDChar checks whether the character is allowed unencoded in a method-specific ID (idchar, see https://www.w3.org/TR/did-core/#did-syntax).
+func isIDChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '.' || c == '_'
+}
+
+// isUnreserved checks whether the character is unreserved as specified by RFC 3986 (section 2.3).
+func isUnreserved(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// normalizeWebID lowercases the domain name of a did:web ID (https://w3c-ccg.github.io/did-method-web/), since domain names are case-insensitive.
+// The path segments are case-sensitive, and are left as-is.
+func normalizeWebID(id string) string {
+	host, path, hasPath := strings.Cut(id, ":")
+	host = strings.ToLower(host)
+	if hasPath {
+		return host + ":" + path
+	}
+	return host
+}
+
+// normalizePKHID lowercases did:pkh IDs (https://github.com/w3c-ccg/did-pkh) of the eip155 (Ethereum) namespace,
+// since EVM addresses are case-insensitive (mixed-case is only used as checksum, see EIP-55).
+// Addresses of other namespaces (e.g. base58 encoded Bitcoin addresses) are case-sensitive, and are left as-is.
+func normalizePKHID(id string) string {
+	if strings.HasPrefix(strings.ToLower(id), "eip155:") {
+		return strings.ToLower(id)
+	}
+	return id
+}
@@ -0,0 +1,99 @@
+package did
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDID_Normalize(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"did:example:123", "did:example:123"},
+		{"did:example:abc%3adef", "did:example:abc%3Adef"},
+		{"did:example:abc%7edef%2d", "did:example:abc%7Edef-"},
+		{"did:example:ABC", "did:example:ABC"},
+		{"did:web:Example.COM", "did:web:example.com"},
+		{"did:web:Example.COM%3a8443:User:Alice", "did:web:example.com%3A8443:User:Alice"},
+		{"did:pkh:eip155:1:0xAb16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb", "did:pkh:eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb"},
+		{"did:pkh:bip122:000000000019d6689c085ae165831e93:128Lkh3S7CkDTBZ8W7BbpsN3YYizJMp8p6", "did:pkh:bip122:000000000019d6689c085ae165831e93:128Lkh3S7CkDTBZ8W7BbpsN3YYizJMp8p6"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			actual := MustParseDID(testCase.input).Normalize()
+
+			assert.Equal(t, testCase.expected, actual.String())
+			assert.Equal(t, MustParseDID(testCase.expected), actual)
+		})
+	}
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, DID{}, DID{}.Normalize())
+	})
+	t.Run("registered normalizer", func(t *testing.T) {
+		RegisterNormalizer("test", func(id string) string {
+			return "normalized-" + id
+		})
+		defer func() {
+			normalizersMux.Lock()
+			delete(normalizers, "test")
+			normalizersMux.Unlock()
+		}()
+
+		assert.Equal(t, "did:test:normalized-123", MustParseDID("did:test:123").Normalize().String())
+	})
+}
+
+func TestDID_EqualsNormalized(t *testing.T) {
+	assert.True(t, MustParseDID("did:web:EXAMPLE.com").EqualsNormalized(MustParseDID("did:web:example.com")))
+	assert.True(t, MustParseDID("did:example:a%3ab").EqualsNormalized(MustParseDID("did:example:a%3Ab")))
+	assert.False(t, MustParseDID("did:example:ABC").EqualsNormalized(MustParseDID("did:example:abc")))
+	assert.False(t, MustParseDID("did:web:example.com:Alice").EqualsNormalized(MustParseDID("did:web:example.com:alice")))
+}
+
+func TestDIDURL_Normalize(t *testing.T) {
+	actual := MustParseDIDURL("did:web:Example.com/a%2fb%7e?x=y#key%2d1%3a").Normalize()
+
+	assert.Equal(t, "did:web:example.com/a%2Fb~?x=y#key-1%3A", actual.String())
+	assert.Equal(t, "a/b~", actual.DecodedPath)
+	assert.Equal(t, "key-1:", actual.DecodedFragment)
+	t.Run("invalid percent-encoding is left as-is", func(t *testing.T) {
+		assert.Equal(t, "abc%zz%4", normalizePercentEncoding("abc%zz%4", isUnreserved))
+	})
+}
+
+func TestDIDURL_EqualsNormalized(t *testing.T) {
+	assert.True(t, MustParseDIDURL("did:web:EXAMPLE.com#key%2D1").EqualsNormalized(MustParseDIDURL("did:web:example.com#key-1")))
+	assert.False(t, MustParseDIDURL("did:web:example.com#Key-1").EqualsNormalized(MustParseDIDURL("did:web:example.com#key-1")))
+}
+
+func TestNormalizedComparison(t *testing.T) {
+	id := MustParseDID("did:web:example.com")
+	vm := &VerificationMethod{ID: MustParseDIDURL("did:web:example.com#key%2d1"), Type: "custom", Controller: id}
+	document := Document{ID: id, Controller: []DID{MustParseDID("did:web:Controller.example.com")}}
+	document.AddAssertionMethod(vm)
+
+	t.Run("VerificationMethods.FindByID", func(t *testing.T) {
+		assert.Same(t, vm, document.VerificationMethod.FindByID(MustParseDIDURL("did:web:EXAMPLE.com#key-1")))
+	})
+	t.Run("VerificationRelationships.FindByID", func(t *testing.T) {
+		document := Document{ID: id}
+		document.AssertionMethod = VerificationRelationships{{VerificationMethod: vm}}
+		assert.Same(t, vm, document.AssertionMethod.FindByID(MustParseDIDURL("did:web:EXAMPLE.com#key-1")))
+	})
+	t.Run("IsController", func(t *testing.T) {
+		assert.True(t, document.IsController(MustParseDID("did:web:controller.example.com")))
+	})
+	t.Run("relative references", func(t *testing.T) {
+		parsed, err := ParseDocument(`{
+			"@context": "https://www.w3.org/ns/did/v1",
+			"id": "did:web:example.com",
+			"verificationMethod": [{"id": "did:web:Example.com#key%2d1", "type": "custom", "controller": "did:web:example.com"}],
+			"authentication": ["#key-1"]
+		}`)
+
+		assert.NoError(t, err)
+		assert.Len(t, parsed.Authentication, 1)
+	})
+}
@@ -0,0 +1,189 @@
+package did
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// SyntaxError describes why a DID or DID URL could not be parsed, and at which byte position in the input.
+// Parse functions return it wrapped in ErrInvalidDID, use errors.As to access it.
+type SyntaxError struct {
+	// Position is the byte offset in the input at which the error was detected.
+	Position int
+	// Reason describes the error.
+	Reason string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Position, e.Reason)
+}
+
+// parseDIDURL parses a DID URL according to the ABNF of DID Core (https://www.w3.org/TR/did-core/#did-url-syntax):
+//
+//	did-url            = did path-abempty [ "?" query ] [ "#" fragment ]
+//	did                = "did:" method-name ":" method-specific-id
+//	method-name        = 1*method-char
+//	method-char        = %x61-7A / DIGIT
+//	method-specific-id = *( *idchar ":" ) 1*idchar
+//	idchar             = ALPHA / DIGIT / "." / "-" / "_" / pct-encoded
+//
+// with path-abempty, query and fragment as specified by RFC 3986. The DID may be omitted, to parse relative DID URLs (e.g. "#key-1").
+// It doesn't allocate unless the input contains percent-encoded characters or a query.
+func parseDIDURL(input string) (DIDURL, error) {
+	var result DIDURL
+	pos := 0
+	if len(input) >= 4 && input[:4] == "did:" {
+		var err error
+		if pos, err = parseDID(input, &result.DID); err != nil {
+			return DIDURL{}, err
+		}
+	} else if input != "" && input[0] != '/' && input[0] != '?' && input[0] != '#' {
+		return DIDURL{}, syntaxError(0, "expected DID (starting with 'did:') or relative DID URL (starting with '/', '?' or '#')")
+	}
+	// path-abempty
+	if pos < len(input) && input[pos] == '/' {
+		start := pos + 1
+		end, err := scanComponent(input, start, isPathChar, "path")
+		if err != nil {
+			return DIDURL{}, err
+		}
+		if end < len(input) && input[end] != '?' && input[end] != '#' {
+			return DIDURL{}, invalidCharacter(input, end, "path")
+		}
+		result.Path = input[start:end]
+		pos = end
+	}
+	// query
+	var query string
+	var queryStart int
+	if pos < len(input) && input[pos] == '?' {
+		start := pos + 1
+		queryStart = start
+		end, err := scanComponent(input, start, isQueryOrFragmentChar, "query")
+		if err != nil {
+			return DIDURL{}, err
+		}
+		if end < len(input) && input[end] != '#' {
+			return DIDURL{}, invalidCharacter(input, end, "query")
+		}
+		query = input[start:end]
+		pos = end
+	}
+	// fragment
+	if pos < len(input) && input[pos] == '#' {
+		start := pos + 1
+		end, err := scanComponent(input, start, isQueryOrFragmentChar, "fragment")
+		if err != nil {
+			return DIDURL{}, err
+		}
+		if end < len(input) {
+			return DIDURL{}, invalidCharacter(input, end, "fragment")
+		}
+		result.Fragment = input[start:end]
+	}
+
+	// percent-encodings have been validated, so unescaping can't fail
+	result.DecodedID, _ = url.PathUnescape(result.ID)
+	result.DecodedPath, _ = url.PathUnescape(result.Path)
+	result.DecodedFragment, _ = url.PathUnescape(result.Fragment)
+	if query != "" {
+		var err error
+		result.Query, err = url.ParseQuery(query)
+		if err != nil {
+			return DIDURL{}, syntaxError(queryStart, "invalid query: "+err.Error())
+		}
+	}
+	return result.cleanup(), nil
+}
+
+// parseDID parses the DID at the start of the input into target, and returns the position after the DID.
+// The input must start with "did:".
+func parseDID(input string, target *DID) (int, error) {
+	// method-name
+	start := len("did:")
+	pos := start
+	for pos < len(input) && isMethodChar(input[pos]) {
+		pos++
+	}
+	if pos < len(input) && input[pos] != ':' {
+		return 0, invalidCharacter(input, pos, "method name")
+	}
+	if pos == start {
+		return 0, syntaxError(pos, "method name must not be empty")
+	}
+	if pos == len(input) {
+		return 0, syntaxError(pos, "expected ':' after method name")
+	}
+	target.Method = input[start:pos]
+	// method-specific-id
+	start = pos + 1
+	pos, err := scanComponent(input, start, isIDCharOrColon, "method-specific ID")
+	if err != nil {
+		return 0, err
+	}
+	if pos < len(input) && input[pos] != '/' && input[pos] != '?' && input[pos] != '#' {
+		return 0, invalidCharacter(input, pos, "method-specific ID")
+	}
+	if pos == start {
+		return 0, syntaxError(pos, "method-specific ID must not be empty")
+	}
+	if input[pos-1] == ':' {
+		return 0, syntaxError(pos-1, "method-specific ID must not end with ':'")
+	}
+	target.ID = input[start:pos]
+	return pos, nil
+}
+
+// scanComponent returns the position of the first character from pos that is neither allowed nor part of a percent-encoding.
+// It returns an error if it encounters an invalid percent-encoding.
+func scanComponent(input string, pos int, allowed func(c byte) bool, component string) (int, error) {
+	for pos < len(input) {
+		c := input[pos]
+		switch {
+		case allowed(c):
+			pos++
+		case c == '%':
+			if pos+2 >= len(input) || !isHex(input[pos+1]) || !isHex(input[pos+2]) {
+				return 0, syntaxError(pos, "invalid percent-encoding in "+component)
+			}
+			pos += 3
+		default:
+			return pos, nil
+		}
+	}
+	return pos, nil
+}
+
+func syntaxError(pos int, reason string) error {
+	return ErrInvalidDID.wrap(&SyntaxError{Position: pos, Reason: reason})
+}
+
+func invalidCharacter(input string, pos int, component string) error {
+	return syntaxError(pos, fmt.Sprintf("invalid character %q in %s", input[pos], component))
+}
+
+func isMethodChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
+
+func isIDCharOrColon(c byte) bool {
+	return isIDChar(c) || c == ':'
+}
+
+// isPChar checks whether the character is a pchar as specified by RFC 3986 (section 3.3), excluding percent-encodings.
+func isPChar(c byte) bool {
+	switch c {
+	case '!', '$', '&', '\'', '(', ')', '*', '+', ',', ';', '=', ':', '@':
+		return true
+	default:
+		return isUnreserved(c)
+	}
+}
+
+func isPathChar(c byte) bool {
+	return isPChar(c) || c == '/'
+}
+
+func isQueryOrFragmentChar(c byte) bool {
+	return isPChar(c) || c == '/' || c == '?'
+}
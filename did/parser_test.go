@@ -0,0 +1,221 @@
+package did
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDIDURL_SyntaxErrors(t *testing.T) {
+	testCases := []struct {
+		input    string
+		position int
+		reason   string
+	}{
+		{"example:123", 0, "expected DID (starting with 'did:') or relative DID URL (starting with '/', '?' or '#')"},
+		{"did:", 4, "method name must not be empty"},
+		{"did::123", 4, "method name must not be empty"},
+		{"did:example", 11, "expected ':' after method name"},
+		{"did:Example:123", 4, `invalid character 'E' in method name`},
+		{"did:example_:123", 11, `invalid character '_' in method name`},
+		{"did:example:", 12, "method-specific ID must not be empty"},
+		{"did:example:#fragment", 12, "method-specific ID must not be empty"},
+		{"did:example:123:", 15, "method-specific ID must not end with ':'"},
+		{"did:example:123::/path", 16, "method-specific ID must not end with ':'"},
+		{"did:example:te@st", 14, `invalid character '@' in method-specific ID`},
+		{"did:example:12%3", 14, "invalid percent-encoding in method-specific ID"},
+		{"did:example:12%zz", 14, "invalid percent-encoding in method-specific ID"},
+		{"did:example:123/pa th", 18, `invalid character ' ' in path`},
+		{"did:example:123/path%2", 20, "invalid percent-encoding in path"},
+		{"did:example:123?a=<b>", 18, `invalid character '<' in query`},
+		{"did:example:123#frag#ment", 20, `invalid character '#' in fragment`},
+		{"#frag ment", 5, `invalid character ' ' in fragment`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			id, err := ParseDIDURL(testCase.input)
+
+			assert.Nil(t, id)
+			assert.ErrorIs(t, err, ErrInvalidDID)
+			var syntaxErr *SyntaxError
+			require.True(t, errors.As(err, &syntaxErr))
+			assert.Equal(t, testCase.position, syntaxErr.Position)
+			assert.Equal(t, testCase.reason, syntaxErr.Reason)
+			assert.EqualError(t, err, fmt.Sprintf("invalid DID: syntax error at position %d: %s", testCase.position, testCase.reason))
+		})
+	}
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := ParseDIDURL("did:example:123?a=b;c")
+
+		assert.ErrorIs(t, err, ErrInvalidDID)
+		var syntaxErr *SyntaxError
+		require.True(t, errors.As(err, &syntaxErr))
+		assert.Equal(t, 16, syntaxErr.Position)
+		assert.EqualError(t, err, "invalid DID: syntax error at position 16: invalid query: invalid semicolon separator in query")
+	})
+}
+
+func TestParseDIDURL_ABNF(t *testing.T) {
+	valid := []string{
+		"did:example:123",
+		"did:example:1:2:3",
+		"did:example::123",
+		"did:example:a.b-c_d",
+		"did:example:%3A%3a",
+		"did:123:456",
+		"did:example:123/",
+		"did:example:123/a/b:c@d!$&'()*+,;=~",
+		"did:example:123?a=b&c=d/e?f",
+		"did:example:123#a/b?c:d@e",
+		"/path",
+		"?query=1",
+		"",
+	}
+	for _, input := range valid {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseDIDURL(input)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseDIDURL_Allocations(t *testing.T) {
+	for _, input := range []string{"did:example:123", "did:web:example.com%3A443:user:alice", "did:example:123/path#key-1"} {
+		t.Run(input, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				_, _ = parseDIDURL(input)
+			})
+			// only percent-decoding of the ID of the did:web DID allocates
+			if strings.Contains(input, "%") {
+				assert.Equal(t, float64(1), allocs)
+			} else {
+				assert.Zero(t, allocs)
+			}
+		})
+	}
+}
+
+func FuzzParseDIDURL(f *testing.F) {
+	for _, seed := range []string{
+		"did:example:123",
+		"did:web:example.com%3A3000:user:alice/foo/bar?param=value#fragment",
+		"did:example:123:",
+		"did:example::123/a/../b?x=1&y=%20#frag%20ment",
+		"#key-1",
+		"?service=foo",
+		"did:Example:123",
+		"did:example:te@st",
+		"did:example:123/pa th",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		parsed, err := ParseDIDURL(input)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidDID) {
+				t.Fatalf("error does not wrap ErrInvalidDID: %v", err)
+			}
+			return
+		}
+		// the ABNF parser is stricter than the regex parser: everything it accepts, the regex parser must accept with the same result
+		expected, err := parseDIDURLRegex(input)
+		if err != nil {
+			t.Fatalf("accepted by parser, but not by regex parser: %q: %v", input, err)
+		}
+		if !assert.Equal(t, *expected, *parsed) {
+			t.Fatalf("different result for %q", input)
+		}
+		// formatting and parsing again must yield the same DID URL
+		reparsed, err := ParseDIDURL(parsed.String())
+		if err != nil {
+			t.Fatalf("formatted DID URL %q (from %q) can't be parsed: %v", parsed.String(), input, err)
+		}
+		if !assert.Equal(t, *parsed, *reparsed) {
+			t.Fatalf("different result after roundtrip of %q", input)
+		}
+	})
+}
+
+func BenchmarkParseDIDURL(b *testing.B) {
+	inputs := map[string]string{
+		"DID":        "did:nuts:B8PUHs2AUHbFF1xLLK4eZjgErEcMXHxs68FteY7NDtCY",
+		"key ID":     "did:web:example.com:iam:2b1cd6e6-fd0b-4ba1-9a1f-c1f1b8c69a0e#0",
+		"escaped":    "did:web:example.com%3A3000:user:alice",
+		"with query": "did:example:123/path?versionTime=2021-05-10T17:00:00Z&service=files#fragment",
+	}
+	for name, input := range inputs {
+		b.Run(name, func(b *testing.B) {
+			b.Run("parser", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := ParseDIDURL(input); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.Run("regex", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := parseDIDURLRegex(input); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+// didURLPattern is the regular expression ParseDIDURL used before it was replaced by an ABNF-conformant parser.
+// It is kept for comparison in fuzz tests and benchmarks.
+var didURLPattern = regexp.MustCompile(`^(did:([a-z0-9]+):((?:(?:[a-zA-Z0-9.\-_:])+|(?:%[0-9a-fA-F]{2})+)+)|)(/.*?|)(\?.*?|)(#.*|)$`)
+
+// parseDIDURLRegex is the regex-based implementation of ParseDIDURL, before it was replaced by an ABNF-conformant parser.
+func parseDIDURLRegex(input string) (*DIDURL, error) {
+	// There are 6 submatches (base 0)
+	// 0. DID + path + query + fragment
+	// 1. DID
+	// 2. method
+	// 3. id
+	// 4. path (starting with '/')
+	// 5. query (starting with '?')
+	// 6. fragment (starting with '#')
+	matches := didURLPattern.FindStringSubmatch(input)
+	if len(matches) == 0 {
+		return nil, ErrInvalidDID
+	}
+
+	result := DIDURL{
+		DID: DID{
+			Method: matches[2],
+			ID:     matches[3],
+		},
+		Path:     strings.TrimPrefix(matches[4], "/"),
+		Fragment: strings.TrimPrefix(matches[6], "#"),
+	}
+	var err error
+	result.DecodedID, err = url.PathUnescape(result.ID)
+	if err != nil {
+		return nil, ErrInvalidDID.wrap(fmt.Errorf("invalid ID: %w", err))
+	}
+	result.DecodedPath, err = url.PathUnescape(result.Path)
+	if err != nil {
+		return nil, ErrInvalidDID.wrap(fmt.Errorf("invalid path: %w", err))
+	}
+	result.DecodedFragment, err = url.PathUnescape(result.Fragment)
+	if err != nil {
+		return nil, ErrInvalidDID.wrap(fmt.Errorf("invalid fragment: %w", err))
+	}
+	result.Query, err = url.ParseQuery(strings.TrimPrefix(matches[5], "?"))
+	if err != nil {
+		return nil, ErrInvalidDID.wrap(err)
+	}
+	result = result.cleanup()
+	return &result, nil
+}
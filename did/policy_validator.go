@@ -0,0 +1,147 @@
+package did
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/multicodec"
+)
+
+// ErrPolicyViolation indicates the DID document violates the cryptographic policy of a PolicyValidator
+var ErrPolicyViolation = errors.New("policy violation")
+
+// PolicyValidator validates the verification methods and controllers of a DID document against a cryptographic policy.
+// All settings are optional: a zero value setting imposes no restriction. Settings apply to this validator only,
+// in contrast to the process-wide minimum RSA key size of the jwx library.
+// It only checks the policy, so it's typically combined with W3CSpecValidator in a MultiValidator.
+type PolicyValidator struct {
+	// AllowedKeyTypes lists the allowed verification method types (e.g. JsonWebKey2020).
+	AllowedKeyTypes []ssi.KeyType
+	// AllowedKeyMaterial lists the allowed key material properties (e.g. only publicKeyJwk and publicKeyMultibase, disallowing publicKeyBase58).
+	AllowedKeyMaterial []KeyMaterialProperty
+	// MinRSAModulusBits specifies the minimum size of RSA keys in bits (e.g. 3072).
+	MinRSAModulusBits int
+	// AllowedCurves lists the allowed curves of EC and OKP keys, by their JWK name: P-256, P-384, P-521, secp256k1, Ed25519, X25519 or BLS12381_G2.
+	// RSA keys are not affected by this setting.
+	AllowedCurves []string
+	// AllowedControllerMethods lists the DID methods (e.g. "web") allowed for controllers of the DID document and its verification methods.
+	AllowedControllerMethods []string
+}
+
+func (p PolicyValidator) Validate(document Document) error {
+	return firstError(p.Report(document))
+}
+
+// Report validates the DID document and returns all policy violations.
+func (p PolicyValidator) Report(document Document) ssi.ValidationReport {
+	return runReporters(document, p)
+}
+
+func (p PolicyValidator) report(document Document, report *ssi.ValidationReport) {
+	for i, controller := range document.Controller {
+		p.reportController(ssi.JSONPointer("controller", i), controller, report)
+	}
+	document.forEachVerificationMethod(func(pointer string, vm *VerificationMethod) {
+		if len(p.AllowedKeyTypes) > 0 && !slices.Contains(p.AllowedKeyTypes, vm.Type) {
+			report.AddError(pointer+ssi.JSONPointer("type"), ErrPolicyViolation, fmt.Sprintf("key type %s is not allowed: %s", vm.Type, vm.ID))
+		}
+		for _, property := range vm.keyMaterialProperties() {
+			if len(p.AllowedKeyMaterial) > 0 && !slices.Contains(p.AllowedKeyMaterial, property) {
+				report.AddError(pointer+ssi.JSONPointer(string(property)), ErrPolicyViolation, fmt.Sprintf("%s is not allowed: %s", property, vm.ID))
+			}
+		}
+		p.reportKey(pointer, vm, report)
+		if !vm.Controller.Empty() {
+			p.reportController(pointer+ssi.JSONPointer("controller"), vm.Controller, report)
+		}
+	})
+}
+
+func (p PolicyValidator) reportController(pointer string, controller DID, report *ssi.ValidationReport) {
+	if len(p.AllowedControllerMethods) > 0 && !slices.Contains(p.AllowedControllerMethods, controller.Method) {
+		report.AddError(pointer, ErrPolicyViolation, fmt.Sprintf("DID method %s is not allowed for controllers: %s", controller.Method, controller))
+	}
+}
+
+// reportKey checks the key size and curve of the verification method's key.
+// If the key size or curve can't be determined (e.g. the key can't be decoded, or it's of an unknown type), it is reported as violation.
+func (p PolicyValidator) reportKey(pointer string, vm *VerificationMethod, report *ssi.ValidationReport) {
+	if p.MinRSAModulusBits == 0 && len(p.AllowedCurves) == 0 {
+		return
+	}
+	modulusBits, curve, err := keyParameters(*vm)
+	if err != nil {
+		report.AddError(pointer, ErrPolicyViolation, fmt.Sprintf("unable to determine key size or curve: %s: %s", vm.ID, err))
+		return
+	}
+	if modulusBits > 0 && modulusBits < p.MinRSAModulusBits {
+		report.AddError(pointer, ErrPolicyViolation, fmt.Sprintf("RSA key size %d is below the minimum of %d bits: %s", modulusBits, p.MinRSAModulusBits, vm.ID))
+	}
+	if curve != "" && len(p.AllowedCurves) > 0 && !slices.Contains(p.AllowedCurves, curve) {
+		report.AddError(pointer, ErrPolicyViolation, fmt.Sprintf("curve %s is not allowed: %s", curve, vm.ID))
+	}
+}
+
+// keyParameters returns the RSA modulus size in bits (for RSA keys) or the curve name (for EC and OKP keys) of the verification method's key.
+// It returns an error if neither can be determined.
+// RSA keys are decoded here instead of through PublicKey(), since that rejects keys below the process-wide minimum size of the jwx library.
+func keyParameters(vm VerificationMethod) (int, string, error) {
+	if vm.PublicKeyJwk != nil {
+		kty, _ := vm.PublicKeyJwk["kty"].(string)
+		switch kty {
+		case "RSA":
+			n, _ := vm.PublicKeyJwk["n"].(string)
+			modulus, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(n, "="))
+			if err != nil {
+				return 0, "", fmt.Errorf("invalid RSA modulus: %w", err)
+			}
+			bits := new(big.Int).SetBytes(modulus).BitLen()
+			if bits == 0 {
+				return 0, "", errors.New("missing RSA modulus")
+			}
+			return bits, "", nil
+		case "EC", "OKP":
+			crv, _ := vm.PublicKeyJwk["crv"].(string)
+			if crv == "" {
+				return 0, "", errors.New("missing curve")
+			}
+			return 0, crv, nil
+		default:
+			return 0, "", fmt.Errorf("unsupported JWK key type: %s", kty)
+		}
+	}
+	var key interface{}
+	var err error
+	if vm.PublicKeyPem != "" {
+		key, err = parsePublicKeyPEM(vm.PublicKeyPem)
+	} else {
+		key, err = vm.PublicKey()
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return k.N.BitLen(), "", nil
+	case *ecdsa.PublicKey:
+		return 0, k.Curve.Params().Name, nil
+	case ed25519.PublicKey:
+		return 0, "Ed25519", nil
+	case *ecdh.PublicKey:
+		if k.Curve() == ecdh.X25519() {
+			return 0, "X25519", nil
+		}
+	case multicodec.BLS12381G2PublicKey:
+		return 0, "BLS12381_G2", nil
+	}
+	return 0, "", fmt.Errorf("unsupported key type: %T", key)
+}
@@ -0,0 +1,223 @@
+package did
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/shengdoushi/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyValidator(t *testing.T) {
+	id := MustParseDID("did:example:123")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	rsaJWK := func(bits int) map[string]interface{} {
+		modulus := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		return map[string]interface{}{
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(modulus.Bytes()),
+			"e":   "AQAB",
+		}
+	}
+	newDocument := func(methods ...*VerificationMethod) Document {
+		result := Document{Context: []interface{}{DIDContextV1URI()}, ID: id}
+		for _, vm := range methods {
+			result.AddAssertionMethod(vm)
+		}
+		return result
+	}
+	newMethod := func(fragment string, keyType ssi.KeyType, key interface{}) *VerificationMethod {
+		vm, err := NewVerificationMethod(MustParseDIDURL(id.String()+"#"+fragment), keyType, id, key)
+		require.NoError(t, err)
+		return vm
+	}
+	findings := func(report ssi.ValidationReport) []string {
+		var result []string
+		for _, finding := range report.Findings {
+			result = append(result, finding.String())
+		}
+		return result
+	}
+	t.Run("zero value allows everything", func(t *testing.T) {
+		input := newDocument(newMethod("1", ssi.JsonWebKey2020, rsaKey.Public()), newMethod("2", ssi.ED25519VerificationKey2018, edKey))
+
+		assert.NoError(t, PolicyValidator{}.Validate(input))
+	})
+	t.Run("allowed key types", func(t *testing.T) {
+		input := newDocument(newMethod("1", ssi.JsonWebKey2020, ecKey.Public()), newMethod("2", ssi.ED25519VerificationKey2018, edKey))
+		validator := PolicyValidator{AllowedKeyTypes: []ssi.KeyType{ssi.JsonWebKey2020, ssi.ED25519VerificationKey2020}}
+
+		report := validator.Report(input)
+
+		assert.Equal(t, []string{"error at /verificationMethod/1/type: key type Ed25519VerificationKey2018 is not allowed: did:example:123#2"}, findings(report))
+		assertIsError(t, ErrPolicyViolation, validator.Validate(input))
+	})
+	t.Run("allowed key material", func(t *testing.T) {
+		vm := newMethod("2", ssi.ED25519VerificationKey2018, edKey)
+		vm.PublicKeyMultibase = ""
+		vm.PublicKeyBase58 = base58.Encode(edKey, base58.BitcoinAlphabet)
+		input := newDocument(newMethod("1", ssi.JsonWebKey2020, ecKey.Public()), vm)
+		validator := PolicyValidator{AllowedKeyMaterial: []KeyMaterialProperty{PublicKeyJwkProperty, PublicKeyMultibaseProperty}}
+
+		report := validator.Report(input)
+
+		assert.Equal(t, []string{"error at /verificationMethod/1/publicKeyBase58: publicKeyBase58 is not allowed: did:example:123#2"}, findings(report))
+	})
+	t.Run("minimum RSA modulus", func(t *testing.T) {
+		validator := PolicyValidator{MinRSAModulusBits: 3072}
+		t.Run("publicKeyJwk", func(t *testing.T) {
+			input := newDocument(newMethod("1", ssi.JsonWebKey2020, rsaKey.Public()))
+
+			report := validator.Report(input)
+
+			assert.Equal(t, []string{"error at /verificationMethod/0: RSA key size 2048 is below the minimum of 3072 bits: did:example:123#1"}, findings(report))
+		})
+		t.Run("publicKeyJwk below jwx minimum", func(t *testing.T) {
+			vm := &VerificationMethod{ID: MustParseDIDURL(id.String() + "#1"), Type: ssi.JsonWebKey2020, Controller: id, PublicKeyJwk: rsaJWK(1024)}
+
+			report := validator.Report(newDocument(vm))
+
+			assert.Equal(t, []string{"error at /verificationMethod/0: RSA key size 1024 is below the minimum of 3072 bits: did:example:123#1"}, findings(report))
+		})
+		t.Run("publicKeyPem", func(t *testing.T) {
+			der, _ := x509.MarshalPKIXPublicKey(rsaKey.Public())
+			vm := &VerificationMethod{
+				ID:           MustParseDIDURL(id.String() + "#1"),
+				Type:         ssi.RSAVerificationKey2018,
+				Controller:   id,
+				PublicKeyPem: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+			}
+
+			report := validator.Report(newDocument(vm))
+
+			assert.Equal(t, []string{"error at /verificationMethod/0: RSA key size 2048 is below the minimum of 3072 bits: did:example:123#1"}, findings(report))
+		})
+		t.Run("ok", func(t *testing.T) {
+			vm := &VerificationMethod{ID: MustParseDIDURL(id.String() + "#1"), Type: ssi.JsonWebKey2020, Controller: id, PublicKeyJwk: rsaJWK(3072)}
+
+			assert.NoError(t, validator.Validate(newDocument(vm)))
+		})
+	})
+	t.Run("allowed curves", func(t *testing.T) {
+		input := newDocument(
+			newMethod("1", ssi.JsonWebKey2020, ecKey.Public()),
+			newMethod("2", ssi.ED25519VerificationKey2020, edKey),
+			newMethod("3", ssi.ECDSASECP256R1VerificationKey2019, ecKeyOn(t, elliptic.P256())),
+			newMethod("4", ssi.JsonWebKey2020, rsaKey.Public()),
+		)
+		validator := PolicyValidator{AllowedCurves: []string{"P-256", "Ed25519"}}
+
+		report := validator.Report(input)
+
+		assert.Equal(t, []string{"error at /verificationMethod/0: curve P-384 is not allowed: did:example:123#1"}, findings(report))
+	})
+	t.Run("key size or curve can't be determined", func(t *testing.T) {
+		validator := PolicyValidator{MinRSAModulusBits: 2048, AllowedCurves: []string{"P-256"}}
+		t.Run("EC JWK without crv", func(t *testing.T) {
+			vm := &VerificationMethod{ID: MustParseDIDURL(id.String() + "#1"), Type: ssi.JsonWebKey2020, Controller: id, PublicKeyJwk: map[string]interface{}{"kty": "EC", "x": "abc", "y": "def"}}
+
+			report := validator.Report(newDocument(vm))
+
+			assert.Equal(t, []string{"error at /verificationMethod/0: unable to determine key size or curve: did:example:123#1: missing curve"}, findings(report))
+		})
+		t.Run("RSA JWK with invalid modulus", func(t *testing.T) {
+			vm := &VerificationMethod{ID: MustParseDIDURL(id.String() + "#1"), Type: ssi.JsonWebKey2020, Controller: id, PublicKeyJwk: map[string]interface{}{"kty": "RSA", "n": "!!!", "e": "AQAB"}}
+
+			report := validator.Report(newDocument(vm))
+
+			require.Len(t, report.Findings, 1)
+			assert.Contains(t, report.Findings[0].String(), "unable to determine key size or curve: did:example:123#1: invalid RSA modulus")
+		})
+		t.Run("publicKeyPem with non-RSA key", func(t *testing.T) {
+			der, _ := x509.MarshalPKIXPublicKey(ecKey.Public())
+			vm := &VerificationMethod{
+				ID:           MustParseDIDURL(id.String() + "#1"),
+				Type:         ssi.JsonWebKey2020,
+				Controller:   id,
+				PublicKeyPem: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+			}
+
+			report := validator.Report(newDocument(vm))
+
+			assert.Equal(t, []string{"error at /verificationMethod/0: curve P-384 is not allowed: did:example:123#1"}, findings(report))
+		})
+		t.Run("invalid publicKeyPem", func(t *testing.T) {
+			vm := &VerificationMethod{ID: MustParseDIDURL(id.String() + "#1"), Type: ssi.RSAVerificationKey2018, Controller: id, PublicKeyPem: "invalid"}
+
+			report := validator.Report(newDocument(vm))
+
+			assert.Equal(t, []string{"error at /verificationMethod/0: unable to determine key size or curve: did:example:123#1: publicKeyPem decode error: no PEM block found"}, findings(report))
+		})
+		t.Run("registered key type with unknown key", func(t *testing.T) {
+			const keyType = ssi.KeyType("TestPQCVerificationKey2024")
+			t.Cleanup(func() {
+				keyTypesMux.Lock()
+				defer keyTypesMux.Unlock()
+				delete(keyTypes, keyType)
+			})
+			require.NoError(t, RegisterKeyType(KeyTypeDefinition{
+				Type:        keyType,
+				KeyMaterial: []KeyMaterialProperty{PublicKeyMultibaseProperty},
+				Encoder: func(key crypto.PublicKey, vm *VerificationMethod) error {
+					vm.PublicKeyMultibase = "u" + string(key.(testPQCPublicKey))
+					return nil
+				},
+				Decoder: func(vm VerificationMethod) (crypto.PublicKey, error) {
+					return testPQCPublicKey(vm.PublicKeyMultibase[1:]), nil
+				},
+			}))
+			input := newDocument(newMethod("1", keyType, testPQCPublicKey("key")))
+
+			report := validator.Report(input)
+
+			assert.Equal(t, []string{"error at /verificationMethod/0: unable to determine key size or curve: did:example:123#1: unsupported key type: did.testPQCPublicKey"}, findings(report))
+			assert.NoError(t, PolicyValidator{AllowedControllerMethods: []string{"example"}}.Validate(input))
+		})
+	})
+	t.Run("allowed controller methods", func(t *testing.T) {
+		vm := newMethod("1", ssi.JsonWebKey2020, ecKey.Public())
+		vm.Controller = MustParseDID("did:key:z6Mkabc")
+		input := newDocument(vm)
+		input.Controller = []DID{id, MustParseDID("did:web:example.com")}
+		validator := PolicyValidator{AllowedControllerMethods: []string{"example", "web"}}
+
+		report := validator.Report(input)
+
+		assert.Equal(t, []string{"error at /verificationMethod/0/controller: DID method key is not allowed for controllers: did:key:z6Mkabc"}, findings(report))
+	})
+	t.Run("embedded verification methods", func(t *testing.T) {
+		input := newDocument()
+		input.KeyAgreement = VerificationRelationships{{VerificationMethod: newMethod("1", ssi.JsonWebKey2020, ecKey.Public())}}
+		validator := PolicyValidator{AllowedCurves: []string{"P-256"}}
+
+		report := validator.Report(input)
+
+		assert.Equal(t, []string{"error at /keyAgreement/0: curve P-384 is not allowed: did:example:123#1"}, findings(report))
+	})
+	t.Run("combined with W3CSpecValidator", func(t *testing.T) {
+		input := newDocument(newMethod("1", ssi.JsonWebKey2020, ecKey.Public()))
+		validator := MultiValidator{Validators: []Validator{W3CSpecValidator{}, PolicyValidator{AllowedCurves: []string{"P-256"}}}}
+
+		assertIsError(t, ErrPolicyViolation, validator.Validate(input))
+	})
+}
+
+func ecKeyOn(t *testing.T, curve elliptic.Curve) *ecdsa.PublicKey {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return &key.PublicKey
+}
@@ -0,0 +1,162 @@
+package did
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	ssi "github.com/nuts-foundation/go-did"
+)
+
+// ErrInvalidAlsoKnownAs indicates the DID Document's `alsoKnownAs` is invalid
+var ErrInvalidAlsoKnownAs = errors.New("invalid alsoKnownAs")
+
+// relationshipErrors maps verification relationships to the error that indicates they are invalid.
+var relationshipErrors = map[RelationshipType]error{
+	AuthenticationRelationship:       ErrInvalidAuthentication,
+	AssertionMethodRelationship:      ErrInvalidAssertionMethod,
+	KeyAgreementRelationship:         ErrInvalidKeyAgreement,
+	CapabilityInvocationRelationship: ErrInvalidCapabilityInvocation,
+	CapabilityDelegationRelationship: ErrInvalidCapabilityDelegation,
+}
+
+// StrictW3CSpecValidator validates a DID document like W3CSpecValidator, and additionally checks the following requirements of
+// the W3C DID Core specification (https://www.w3.org/TR/did-core/):
+//   - the IDs of verification methods (including those embedded in verification relationships) are unique, and so are the IDs of services,
+//   - verification method IDs are DID URLs (after resolving relative references against the document ID),
+//   - the document and verification method controllers are valid DIDs,
+//   - references in verification relationships resolve to a verification method in the document,
+//   - service endpoints that are strings are valid, absolute URIs,
+//   - ordered sets (@context, controller, alsoKnownAs, verification relationships and service endpoint sets) contain no duplicates,
+//   - alsoKnownAs entries are valid, absolute URIs.
+type StrictW3CSpecValidator struct{}
+
+func (s StrictW3CSpecValidator) Validate(document Document) error {
+	return firstError(s.Report(document))
+}
+
+// Report validates the DID document and returns all findings.
+func (s StrictW3CSpecValidator) Report(document Document) ssi.ValidationReport {
+	result := W3CSpecValidator{}.Report(document)
+	result.Append(runReporters(document, s))
+	return result
+}
+
+func (s StrictW3CSpecValidator) report(document Document, report *ssi.ValidationReport) {
+	reportDuplicates(document.Context, ssi.JSONPointer("@context"), ErrInvalidContext, report)
+	reportDuplicates(document.Controller, ssi.JSONPointer("controller"), ErrInvalidController, report)
+	for i, controller := range document.Controller {
+		if !controller.Empty() && !isValidDID(controller) {
+			report.AddError(ssi.JSONPointer("controller", i), ErrInvalidController, "controller is not a valid DID: "+controller.String())
+		}
+	}
+	reportDuplicates(document.AlsoKnownAs, ssi.JSONPointer("alsoKnownAs"), ErrInvalidAlsoKnownAs, report)
+	for i, alias := range document.AlsoKnownAs {
+		if !isAbsoluteURI(alias.String()) {
+			report.AddError(ssi.JSONPointer("alsoKnownAs", i), ErrInvalidAlsoKnownAs, "alsoKnownAs entry is not a valid URI: "+alias.String())
+		}
+	}
+	s.reportVerificationMethods(document, report)
+	for _, relationshipType := range relationshipTypes {
+		s.reportRelationship(document, relationshipType, report)
+	}
+	s.reportServices(document, report)
+}
+
+func (s StrictW3CSpecValidator) reportVerificationMethods(document Document, report *ssi.ValidationReport) {
+	seen := map[string]string{}
+	document.forEachVerificationMethod(func(pointer string, vm *VerificationMethod) {
+		if vm.ID.Empty() {
+			// already reported by W3CSpecValidator
+			return
+		}
+		id := relativeURLToAbsoluteURL(document.ID, vm.ID)
+		if id.DID.Empty() || !isValidDID(id.DID) {
+			report.AddError(pointer+ssi.JSONPointer("id"), ErrInvalidVerificationMethod, "id is not a DID URL: "+vm.ID.String())
+		} else if other, exists := seen[id.String()]; exists {
+			report.AddError(pointer+ssi.JSONPointer("id"), ErrInvalidVerificationMethod, fmt.Sprintf("id is not unique (also used by %s): %s", other, id))
+		} else {
+			seen[id.String()] = pointer
+		}
+		if !vm.Controller.Empty() && !isValidDID(vm.Controller) {
+			report.AddError(pointer+ssi.JSONPointer("controller"), ErrInvalidVerificationMethod, "controller is not a valid DID: "+vm.Controller.String())
+		}
+	})
+}
+
+func (s StrictW3CSpecValidator) reportRelationship(document Document, relationshipType RelationshipType, report *ssi.ValidationReport) {
+	code := relationshipErrors[relationshipType]
+	seen := map[string]bool{}
+	for i, relationship := range *document.relationship(relationshipType) {
+		if relationship.reference.Empty() {
+			continue
+		}
+		pointer := ssi.JSONPointer(string(relationshipType), i)
+		reference := relativeURLToAbsoluteURL(document.ID, relationship.reference)
+		if seen[reference.String()] {
+			report.AddError(pointer, code, "duplicate entry in ordered set: "+relationship.reference.String())
+		}
+		seen[reference.String()] = true
+		if document.findVerificationMethod(reference) == nil {
+			report.AddError(pointer, code, "reference does not resolve to a verification method: "+relationship.reference.String())
+		}
+	}
+}
+
+func (s StrictW3CSpecValidator) reportServices(document Document, report *ssi.ValidationReport) {
+	seen := map[string]int{}
+	for i, service := range document.Service {
+		pointer := ssi.JSONPointer("service", i)
+		if service.ID.String() != "" {
+			id := relativeURIToAbsoluteURI(document.ID, service.ID).String()
+			if other, exists := seen[id]; exists {
+				report.AddError(pointer+ssi.JSONPointer("id"), ErrInvalidService, fmt.Sprintf("id is not unique (also used by %s): %s", ssi.JSONPointer("service", other), id))
+			} else {
+				seen[id] = i
+			}
+		}
+		endpointPointer := pointer + ssi.JSONPointer("serviceEndpoint")
+		switch endpoint := service.ServiceEndpoint.(type) {
+		case string:
+			if !isAbsoluteURI(endpoint) {
+				report.AddError(endpointPointer, ErrInvalidService, "serviceEndpoint is not a valid URI: "+endpoint)
+			}
+		case []interface{}:
+			for j, curr := range endpoint {
+				if str, ok := curr.(string); ok && !isAbsoluteURI(str) {
+					report.AddError(endpointPointer+ssi.JSONPointer(j), ErrInvalidService, "serviceEndpoint is not a valid URI: "+str)
+				}
+			}
+			reportDuplicates(endpoint, endpointPointer, ErrInvalidService, report)
+		}
+	}
+}
+
+// reportDuplicates reports entries of the ordered set at the given JSON pointer that occur more than once.
+// Entries are compared by their JSON representation.
+func reportDuplicates[T any](set []T, pointer string, code error, report *ssi.ValidationReport) {
+	seen := map[string]bool{}
+	for i, entry := range set {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		if seen[string(data)] {
+			report.AddError(pointer+ssi.JSONPointer(i), code, "duplicate entry in ordered set: "+string(data))
+		}
+		seen[string(data)] = true
+	}
+}
+
+// isValidDID checks whether the DID conforms to the DID syntax, e.g. when it was constructed programmatically.
+func isValidDID(d DID) bool {
+	parsed, err := ParseDID(d.String())
+	return err == nil && parsed.String() == d.String()
+}
+
+// isAbsoluteURI checks whether the given string is a valid URI (RFC 3986) with a scheme.
+func isAbsoluteURI(input string) bool {
+	parsed, err := url.Parse(input)
+	return err == nil && parsed.Scheme != ""
+}
@@ -0,0 +1,141 @@
+package did
+
+import (
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictW3CSpecValidator(t *testing.T) {
+	// reportFor returns the findings of the strict validator as strings, omitting the findings of W3CSpecValidator.
+	reportFor := func(t *testing.T, document Document) []string {
+		report := StrictW3CSpecValidator{}.Report(document)
+		base := W3CSpecValidator{}.Report(document)
+		var result []string
+		for _, finding := range report.Findings[len(base.Findings):] {
+			result = append(result, finding.String())
+		}
+		return result
+	}
+	t.Run("ok", func(t *testing.T) {
+		assert.NoError(t, StrictW3CSpecValidator{}.Validate(document()))
+		assert.Empty(t, StrictW3CSpecValidator{}.Report(document()).Findings)
+	})
+	t.Run("ok - parsed document", func(t *testing.T) {
+		document, err := ParseDocument(`{
+			"@context": ["https://www.w3.org/ns/did/v1"],
+			"id": "did:example:123",
+			"alsoKnownAs": ["https://example.com/alice"],
+			"verificationMethod": [{"id": "#key-1", "type": "custom", "controller": "did:example:123"}],
+			"authentication": ["#key-1", {"id": "did:example:123#key-2", "type": "custom", "controller": "did:example:123"}],
+			"service": [{"id": "#service-1", "type": "custom", "serviceEndpoint": ["https://example.com", {"uri": "https://example.com"}]}]
+		}`)
+		require.NoError(t, err)
+		assert.NoError(t, StrictW3CSpecValidator{}.Validate(*document))
+	})
+	t.Run("W3CSpecValidator findings are included", func(t *testing.T) {
+		input := document()
+		input.ID = DID{}
+		err := StrictW3CSpecValidator{}.Validate(input)
+		assertIsError(t, ErrInvalidID, err)
+	})
+	t.Run("verification method IDs must be unique", func(t *testing.T) {
+		input := document()
+		duplicate := *input.VerificationMethod[0]
+		input.VerificationMethod = append(input.VerificationMethod, &duplicate)
+		input.KeyAgreement = VerificationRelationships{{VerificationMethod: &duplicate}}
+
+		actual := reportFor(t, input)
+
+		assert.Equal(t, []string{
+			"error at /verificationMethod/1/id: id is not unique (also used by /verificationMethod/0): did:test:12345#key-1",
+			"error at /keyAgreement/0/id: id is not unique (also used by /verificationMethod/0): did:test:12345#key-1",
+		}, actual)
+		assertIsError(t, ErrInvalidVerificationMethod, StrictW3CSpecValidator{}.Validate(input))
+	})
+	t.Run("verification method ID must be a DID URL", func(t *testing.T) {
+		input := document()
+		input.ID = DID{}
+		input.VerificationMethod[0].ID = DIDURL{Fragment: "key-1"}
+		input.Authentication = nil
+		input.AssertionMethod = nil
+
+		actual := reportFor(t, input)
+
+		assert.Equal(t, []string{"error at /verificationMethod/0/id: id is not a DID URL: #key-1"}, actual)
+	})
+	t.Run("controllers must be valid DIDs", func(t *testing.T) {
+		input := document()
+		input.Controller = []DID{{Method: "Invalid Method", ID: "123"}}
+		input.VerificationMethod[0].Controller = DID{Method: "example", ID: "12 3"}
+
+		actual := reportFor(t, input)
+
+		assert.Equal(t, []string{
+			"error at /controller/0: controller is not a valid DID: did:Invalid Method:123",
+			"error at /verificationMethod/0/controller: controller is not a valid DID: did:example:12 3",
+		}, actual)
+	})
+	t.Run("relationship references must resolve", func(t *testing.T) {
+		input := document()
+		input.CapabilityInvocation = VerificationRelationships{
+			{VerificationMethod: input.VerificationMethod[0], reference: MustParseDIDURL("did:test:12345#unknown")},
+		}
+
+		actual := reportFor(t, input)
+
+		assert.Equal(t, []string{"error at /capabilityInvocation/0: reference does not resolve to a verification method: did:test:12345#unknown"}, actual)
+		assertIsError(t, ErrInvalidCapabilityInvocation, StrictW3CSpecValidator{}.Validate(input))
+	})
+	t.Run("ordered sets must not contain duplicates", func(t *testing.T) {
+		input := document()
+		input.Context = append(input.Context, DIDContextV1URI())
+		input.Controller = append(input.Controller, input.Controller[0])
+		input.AlsoKnownAs = []ssi.URI{ssi.MustParseURI("https://example.com"), ssi.MustParseURI("https://example.com")}
+		input.AddAuthenticationMethod(input.VerificationMethod[0])
+		input.Authentication = append(input.Authentication, input.Authentication[0])
+		input.Service[0].ServiceEndpoint = []interface{}{"https://example.com", "https://example.com"}
+
+		actual := reportFor(t, input)
+
+		assert.Equal(t, []string{
+			`error at /@context/2: duplicate entry in ordered set: "https://www.w3.org/ns/did/v1"`,
+			`error at /controller/1: duplicate entry in ordered set: "did:test:12345"`,
+			`error at /alsoKnownAs/1: duplicate entry in ordered set: "https://example.com"`,
+			`error at /authentication/1: duplicate entry in ordered set: did:test:12345#key-1`,
+			`error at /service/0/serviceEndpoint/1: duplicate entry in ordered set: "https://example.com"`,
+		}, actual)
+	})
+	t.Run("alsoKnownAs entries must be URIs", func(t *testing.T) {
+		input := document()
+		input.AlsoKnownAs = []ssi.URI{ssi.MustParseURI("not-a-uri")}
+
+		actual := reportFor(t, input)
+
+		assert.Equal(t, []string{"error at /alsoKnownAs/0: alsoKnownAs entry is not a valid URI: not-a-uri"}, actual)
+		assertIsError(t, ErrInvalidAlsoKnownAs, StrictW3CSpecValidator{}.Validate(input))
+	})
+	t.Run("service IDs must be unique", func(t *testing.T) {
+		input := document()
+		input.Service = append(input.Service, input.Service[0])
+
+		actual := reportFor(t, input)
+
+		assert.Equal(t, []string{"error at /service/1/id: id is not unique (also used by /service/0): did:test:12345#service-1"}, actual)
+	})
+	t.Run("service endpoints must be URIs", func(t *testing.T) {
+		input := document()
+		input.Service = append(input.Service, Service{ID: ssi.MustParseURI("#service-2"), Type: "custom", ServiceEndpoint: []interface{}{"https://example.com", "no-uri"}})
+		input.Service[0].ServiceEndpoint = "no-uri"
+
+		actual := reportFor(t, input)
+
+		assert.Equal(t, []string{
+			"error at /service/0/serviceEndpoint: serviceEndpoint is not a valid URI: no-uri",
+			"error at /service/1/serviceEndpoint/1: serviceEndpoint is not a valid URI: no-uri",
+		}, actual)
+		assertIsError(t, ErrInvalidService, StrictW3CSpecValidator{}.Validate(input))
+	})
+}
@@ -22,6 +22,9 @@ var ErrInvalidController = errors.New("invalid controller")
 // ErrInvalidVerificationMethod indicates the verificationMethod is invalid (e.g. invalid `id` or `type`)
 var ErrInvalidVerificationMethod = errors.New("invalid verificationMethod")
 
+// ErrInvalidKeyMaterial indicates the key material of a verification method is invalid (e.g. it contains a private key, or has the wrong size or curve)
+var ErrInvalidKeyMaterial = errors.New("invalid key material")
+
 // ErrInvalidAuthentication indicates the authentication is invalid (e.g. invalid `id` or `type`)
 var ErrInvalidAuthentication = errors.New("invalid authentication")
 
@@ -40,12 +43,22 @@ var ErrInvalidCapabilityDelegation = errors.New("invalid capabilityDelegation")
 // ErrInvalidService indicates the service is invalid (e.g. invalid `id` or `type`)
 var ErrInvalidService = errors.New("invalid service")
 
+// ErrDeprecatedProperty indicates the DID Document uses a deprecated property (e.g. `publicKeyBase58`). It is reported as warning.
+var ErrDeprecatedProperty = errors.New("deprecated property")
+
 // Validator defines functions for validating a DID document.
 type Validator interface {
 	// Validate validates a DID document. It returns the first validation error is finds wrapped in ErrDIDDocumentInvalid.
 	Validate(document Document) error
 }
 
+// ReportingValidator is a Validator that can also report all its findings, instead of only the first error.
+type ReportingValidator interface {
+	Validator
+	// Report validates a DID document and returns all findings, including warnings.
+	Report(document Document) ssi.ValidationReport
+}
+
 // MultiValidator is a validator that executes zero or more validators. It returns the first validation error it encounters.
 type MultiValidator struct {
 	Validators []Validator
@@ -60,131 +73,197 @@ func (m MultiValidator) Validate(document Document) error {
 	return nil
 }
 
+// Report executes all validators and combines their findings.
+// Validators that don't implement ReportingValidator contribute their validation error (if any) as a single finding without JSON pointer.
+func (m MultiValidator) Report(document Document) ssi.ValidationReport {
+	result := ssi.ValidationReport{}
+	for _, validator := range m.Validators {
+		if reporter, ok := validator.(ReportingValidator); ok {
+			result.Append(reporter.Report(document))
+		} else if err := validator.Validate(document); err != nil {
+			result.AddError("", err, err.Error())
+		}
+	}
+	return result
+}
+
 // W3CSpecValidator validates a DID document according to the W3C DID Core Data Model specification (https://www.w3.org/TR/did-core/).
 type W3CSpecValidator struct {
 }
 
 func (w W3CSpecValidator) Validate(document Document) error {
-	return MultiValidator{[]Validator{
+	return firstError(w.Report(document))
+}
+
+// Report validates the DID document and returns all findings. Use of deprecated properties (publicKeyBase58, publicKeyPem) is reported as warning.
+func (w W3CSpecValidator) Report(document Document) ssi.ValidationReport {
+	return runReporters(document,
 		baseValidator{},
 		verificationMethodValidator{},
-		verificationMethodRelationshipValidator{
-			getter: func(document Document) VerificationRelationships {
-				return document.Authentication
-			},
-			err: ErrInvalidAuthentication,
-		},
-		verificationMethodRelationshipValidator{
-			getter: func(document Document) VerificationRelationships {
-				return document.AssertionMethod
-			},
-			err: ErrInvalidAssertionMethod,
-		},
-		verificationMethodRelationshipValidator{
-			getter: func(document Document) VerificationRelationships {
-				return document.KeyAgreement
-			},
-			err: ErrInvalidKeyAgreement,
-		},
-		verificationMethodRelationshipValidator{
-			getter: func(document Document) VerificationRelationships {
-				return document.CapabilityInvocation
-			},
-			err: ErrInvalidCapabilityInvocation,
-		},
-		verificationMethodRelationshipValidator{
-			getter: func(document Document) VerificationRelationships {
-				return document.CapabilityDelegation
-			},
-			err: ErrInvalidCapabilityDelegation,
-		},
+		verificationMethodRelationshipValidator{relationship: AuthenticationRelationship, err: ErrInvalidAuthentication},
+		verificationMethodRelationshipValidator{relationship: AssertionMethodRelationship, err: ErrInvalidAssertionMethod},
+		verificationMethodRelationshipValidator{relationship: KeyAgreementRelationship, err: ErrInvalidKeyAgreement},
+		verificationMethodRelationshipValidator{relationship: CapabilityInvocationRelationship, err: ErrInvalidCapabilityInvocation},
+		verificationMethodRelationshipValidator{relationship: CapabilityDelegationRelationship, err: ErrInvalidCapabilityDelegation},
 		serviceValidator{},
-	}}.Validate(document)
+	)
+}
+
+// reporter is implemented by the validators that make up W3CSpecValidator.
+type reporter interface {
+	report(document Document, report *ssi.ValidationReport)
+}
+
+func runReporters(document Document, reporters ...reporter) ssi.ValidationReport {
+	result := ssi.ValidationReport{}
+	for _, curr := range reporters {
+		curr.report(document, &result)
+	}
+	return result
+}
+
+// firstError returns the code of the first error finding wrapped in ErrDIDDocumentInvalid, or nil if there are no errors.
+func firstError(report ssi.ValidationReport) error {
+	errs := report.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return makeValidationError(errs[0].Code)
 }
 
 // baseValidator validates simple top-level DID document properties (@context, ID, controller)
 type baseValidator struct{}
 
-func (w baseValidator) Validate(document Document) error {
+func (w baseValidator) report(document Document, report *ssi.ValidationReport) {
 	// Verify `@context`
 	if !containsContextURI(document, DIDContextV1) {
-		return makeValidationError(ErrInvalidContext)
+		report.AddError(ssi.JSONPointer("@context"), ErrInvalidContext, "@context must contain "+DIDContextV1)
 	}
 	// Verify `id`
 	if document.ID.Empty() {
-		return makeValidationError(ErrInvalidID)
+		report.AddError(ssi.JSONPointer("id"), ErrInvalidID, "id is required")
 	}
 	// Verify `controller`
-	for _, controller := range document.Controller {
+	for i, controller := range document.Controller {
 		if controller.Empty() {
-			return makeValidationError(ErrInvalidController)
+			report.AddError(ssi.JSONPointer("controller", i), ErrInvalidController, "controller must not be empty")
 		}
 	}
-	return nil
 }
 
 type verificationMethodValidator struct{}
 
-func (v verificationMethodValidator) Validate(document Document) error {
-	for _, vm := range document.VerificationMethod {
-		if !validateVM(vm) {
-			return makeValidationError(ErrInvalidVerificationMethod)
-		}
+func (v verificationMethodValidator) report(document Document, report *ssi.ValidationReport) {
+	for i, vm := range document.VerificationMethod {
+		reportVM(vm, ssi.JSONPointer(verificationMethodKey, i), ErrInvalidVerificationMethod, report)
 	}
-	return nil
 }
 
 type verificationMethodRelationshipValidator struct {
-	getter func(document Document) VerificationRelationships
-	err    error
+	relationship RelationshipType
+	err          error
 }
 
-func (v verificationMethodRelationshipValidator) Validate(document Document) error {
-	for _, vm := range v.getter(document) {
-		if !validateVM(vm.VerificationMethod) {
-			return makeValidationError(v.err)
+func (v verificationMethodRelationshipValidator) report(document Document, report *ssi.ValidationReport) {
+	for i, relationship := range *document.relationship(v.relationship) {
+		pointer := ssi.JSONPointer(string(v.relationship), i)
+		if relationship.reference.Empty() {
+			reportVM(relationship.VerificationMethod, pointer, v.err, report)
+			continue
+		}
+		// Referenced verification methods are reported at their position in verificationMethod, so only report that the reference is invalid.
+		if relationship.VerificationMethod == nil {
+			report.AddError(pointer, v.err, "reference can't be resolved: "+relationship.reference.String())
+		} else if len(vmErrors(relationship.VerificationMethod)) > 0 {
+			report.AddError(pointer, v.err, "references an invalid verification method: "+relationship.reference.String())
 		}
 	}
-	return nil
 }
 
-func validateVM(vm *VerificationMethod) bool {
+// reportVM reports the findings for a verification method, located at the given JSON pointer.
+func reportVM(vm *VerificationMethod, pointer string, code error, report *ssi.ValidationReport) {
+	if vm == nil {
+		report.AddError(pointer, code, "verification method is missing")
+		return
+	}
+	for _, curr := range vmErrors(vm) {
+		report.AddError(pointer+ssi.JSONPointer(curr.property), code, curr.message)
+	}
+	if vm.PublicKeyBase58 != "" {
+		report.AddWarning(pointer+ssi.JSONPointer(PublicKeyBase58Property), ErrDeprecatedProperty, "publicKeyBase58 is deprecated, use publicKeyMultibase or publicKeyJwk instead")
+	}
+	if vm.PublicKeyPem != "" {
+		report.AddWarning(pointer+ssi.JSONPointer(PublicKeyPemProperty), ErrDeprecatedProperty, "publicKeyPem is deprecated, use publicKeyMultibase or publicKeyJwk instead")
+	}
+}
+
+type propertyError struct {
+	property string
+	message  string
+}
+
+// vmErrors returns the invalid properties of the verification method.
+func vmErrors(vm *VerificationMethod) []propertyError {
+	var result []propertyError
 	if vm.ID.Empty() {
-		return false
+		result = append(result, propertyError{property: "id", message: "id is required"})
 	}
 	if len(strings.TrimSpace(string(vm.Type))) == 0 {
-		return false
+		result = append(result, propertyError{property: "type", message: "type is required"})
 	}
 	if vm.Controller.Empty() {
-		return false
+		result = append(result, propertyError{property: "controller", message: "controller is required"})
 	}
-	return true
+	return result
+}
+
+// KeyMaterialValidator validates the key material of all verification methods in a DID document, including those embedded in verification relationships.
+// It checks that no private key material is present, that the key material is in a property allowed for the verification method type,
+// and that it passes the sanity checks of the key type (e.g. key size, curve). Verification methods of unregistered types are only checked for private key material and decodability.
+type KeyMaterialValidator struct{}
+
+func (k KeyMaterialValidator) Validate(document Document) error {
+	return firstError(k.Report(document))
+}
+
+// Report validates the key material of all verification methods and returns all findings.
+func (k KeyMaterialValidator) Report(document Document) ssi.ValidationReport {
+	return runReporters(document, k)
+}
+
+func (k KeyMaterialValidator) report(document Document, report *ssi.ValidationReport) {
+	document.forEachVerificationMethod(func(pointer string, vm *VerificationMethod) {
+		err := vm.validateKeyMaterial()
+		if err == nil {
+			if definition, ok := LookupKeyType(vm.Type); ok {
+				err = definition.checkKeyMaterial(*vm)
+			}
+		}
+		if err != nil {
+			report.AddError(pointer, fmt.Errorf("%w: %s: %w", ErrInvalidKeyMaterial, vm.ID, err), err.Error())
+		}
+	})
 }
 
 type serviceValidator struct{}
 
-func (s serviceValidator) Validate(document Document) error {
-	for _, service := range document.Service {
+func (s serviceValidator) report(document Document, report *ssi.ValidationReport) {
+	for i, service := range document.Service {
+		pointer := ssi.JSONPointer("service", i)
 		if len(strings.TrimSpace(service.ID.String())) == 0 {
-			return makeValidationError(ErrInvalidService)
+			report.AddError(pointer+ssi.JSONPointer("id"), ErrInvalidService, "id is required")
 		}
 		if len(strings.TrimSpace(service.Type)) == 0 {
-			return makeValidationError(ErrInvalidService)
+			report.AddError(pointer+ssi.JSONPointer("type"), ErrInvalidService, "type is required")
 		}
-		if service.ServiceEndpoint == nil {
-			return makeValidationError(ErrInvalidService)
-		}
-		switch x := service.ServiceEndpoint.(type) {
-		case string:
-		case map[string]interface{}:
-		case []interface{}:
-			_ = x // We need to 'do' something with 'x'
-			break
+		switch service.ServiceEndpoint.(type) {
+		case string, map[string]interface{}, []interface{}:
+		case nil:
+			report.AddError(pointer+ssi.JSONPointer("serviceEndpoint"), ErrInvalidService, "serviceEndpoint is required")
 		default:
-			return makeValidationError(ErrInvalidService)
+			report.AddError(pointer+ssi.JSONPointer("serviceEndpoint"), ErrInvalidService, "serviceEndpoint must be a string, map or set")
 		}
 	}
-	return nil
 }
 
 func containsContextURI(document Document, ctx string) bool {
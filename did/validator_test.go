@@ -133,6 +133,134 @@ func TestW3CSpecValidator(t *testing.T) {
 	})
 }
 
+func TestW3CSpecValidator_Report(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		report := W3CSpecValidator{}.Report(document())
+		assert.True(t, report.Valid())
+		assert.Empty(t, report.Findings)
+	})
+	t.Run("all findings", func(t *testing.T) {
+		input := document()
+		input.ID = DID{}
+		input.Controller = append(input.Controller, DID{})
+		input.VerificationMethod = append(input.VerificationMethod, &VerificationMethod{ID: MustParseDIDURL("did:test:12345#key-2")})
+		input.AssertionMethod = append(input.AssertionMethod, VerificationRelationship{VerificationMethod: &VerificationMethod{
+			ID:              MustParseDIDURL("did:test:12345#key-3"),
+			Type:            ssi.ED25519VerificationKey2018,
+			Controller:      MustParseDID("did:test:12345"),
+			PublicKeyBase58: "abc",
+		}})
+		input.Service[0].Type = ""
+		input.Service[0].ServiceEndpoint = nil
+
+		report := W3CSpecValidator{}.Report(input)
+
+		assert.False(t, report.Valid())
+		var actual []string
+		for _, finding := range report.Findings {
+			actual = append(actual, finding.String())
+		}
+		assert.Equal(t, []string{
+			"error at /id: id is required",
+			"error at /controller/1: controller must not be empty",
+			"error at /verificationMethod/1/type: type is required",
+			"error at /verificationMethod/1/controller: controller is required",
+			"warning at /assertionMethod/1/publicKeyBase58: publicKeyBase58 is deprecated, use publicKeyMultibase or publicKeyJwk instead",
+			"error at /service/0/type: type is required",
+			"error at /service/0/serviceEndpoint: serviceEndpoint is required",
+		}, actual)
+		assertIsError(t, ErrInvalidVerificationMethod, report.Findings[2].Code)
+		assertIsError(t, ErrDeprecatedProperty, report.Warnings()[0].Code)
+		// Validate returns the first error
+		err := W3CSpecValidator{}.Validate(input)
+		assertIsError(t, ErrDIDDocumentInvalid, err)
+		assertIsError(t, ErrInvalidID, err)
+	})
+	t.Run("warnings don't fail validation", func(t *testing.T) {
+		input := document()
+		input.VerificationMethod[0].PublicKeyJwk = nil
+		input.VerificationMethod[0].PublicKeyPem = "-----BEGIN PUBLIC KEY-----"
+
+		report := W3CSpecValidator{}.Report(input)
+
+		assert.True(t, report.Valid())
+		require.Len(t, report.Warnings(), 1)
+		assert.Equal(t, "/verificationMethod/0/publicKeyPem", report.Warnings()[0].Pointer)
+		assert.NoError(t, W3CSpecValidator{}.Validate(input))
+	})
+	t.Run("invalid referenced verification method", func(t *testing.T) {
+		input := document()
+		input.VerificationMethod[0].Controller = DID{}
+
+		report := W3CSpecValidator{}.Report(input)
+
+		var pointers []string
+		for _, finding := range report.Errors() {
+			pointers = append(pointers, finding.Pointer)
+		}
+		assert.Equal(t, []string{"/verificationMethod/0/controller", "/authentication/0", "/assertionMethod/0"}, pointers)
+		assertIsError(t, ErrInvalidAuthentication, report.Errors()[1].Code)
+	})
+	t.Run("unresolved reference", func(t *testing.T) {
+		input := document()
+		input.Authentication = VerificationRelationships{{reference: MustParseDIDURL("did:test:12345#unknown")}}
+
+		report := W3CSpecValidator{}.Report(input)
+
+		require.Len(t, report.Errors(), 1)
+		assert.Equal(t, "/authentication/0", report.Errors()[0].Pointer)
+		assert.Equal(t, "reference can't be resolved: did:test:12345#unknown", report.Errors()[0].Message)
+	})
+}
+
+func TestKeyMaterialValidator(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		assert.NoError(t, KeyMaterialValidator{}.Validate(document()))
+	})
+	t.Run("private key in publicKeyJwk", func(t *testing.T) {
+		input := document()
+		input.VerificationMethod[0].PublicKeyJwk["d"] = "secret"
+		err := KeyMaterialValidator{}.Validate(input)
+		assertIsError(t, ErrInvalidKeyMaterial, err)
+		assertIsError(t, ErrPrivateKeyMaterial, err)
+		assert.EqualError(t, err, "DID Document validation failed: invalid key material: did:test:12345#key-1: verification method must not contain private key material: publicKeyJwk contains 'd'")
+	})
+	t.Run("key material in property not allowed for type", func(t *testing.T) {
+		input := document()
+		input.VerificationMethod[0].Type = ssi.ED25519VerificationKey2018
+		err := KeyMaterialValidator{}.Validate(input)
+		assertIsError(t, ErrInvalidKeyMaterial, err)
+		assert.ErrorContains(t, err, "publicKeyJwk is not allowed for verification method type Ed25519VerificationKey2018")
+	})
+	t.Run("curve doesn't match type", func(t *testing.T) {
+		input := document()
+		input.VerificationMethod[0].Type = ssi.ECDSASECP256K1VerificationKey2019
+		err := KeyMaterialValidator{}.Validate(input)
+		assertIsError(t, ErrInvalidKeyMaterial, err)
+		assert.ErrorContains(t, err, "publicKeyJwk: expected curve secp256k1 for EcdsaSecp256k1VerificationKey2019, got 'P-256'")
+	})
+	t.Run("embedded verification method", func(t *testing.T) {
+		input := document()
+		input.KeyAgreement = VerificationRelationships{{VerificationMethod: &VerificationMethod{
+			ID:                 MustParseDIDURL("did:test:12345#key-2"),
+			Type:               ssi.Multikey,
+			Controller:         input.ID,
+			PublicKeyMultibase: "z6Mk",
+		}}}
+		err := KeyMaterialValidator{}.Validate(input)
+		assertIsError(t, ErrInvalidKeyMaterial, err)
+		assert.ErrorContains(t, err, "did:test:12345#key-2")
+	})
+	t.Run("unregistered type - only generic checks", func(t *testing.T) {
+		input := document()
+		input.VerificationMethod[0].Type = "SomeUnknownType"
+		assert.NoError(t, KeyMaterialValidator{}.Validate(input))
+		input.VerificationMethod[0].PublicKeyJwk = nil
+		input.VerificationMethod[0].PublicKeyMultibase = "not-multibase"
+		assertIsError(t, ErrInvalidKeyMaterial, KeyMaterialValidator{}.Validate(input))
+	})
+}
+
 func TestMultiValidator(t *testing.T) {
 	t.Run("no validators", func(t *testing.T) {
 		assert.NoError(t, MultiValidator{}.Validate(document()))
@@ -148,6 +276,23 @@ func TestMultiValidator(t *testing.T) {
 		}}
 		assert.Error(t, MultiValidator{Validators: []Validator{v2, v1}}.Validate(document()))
 	})
+	t.Run("report", func(t *testing.T) {
+		input := document()
+		input.ID = DID{}
+		input.VerificationMethod[0].PublicKeyJwk["d"] = "secret"
+		failing := funcValidator{fn: func(_ Document) error {
+			return errors.New("failed")
+		}}
+
+		report := MultiValidator{Validators: []Validator{W3CSpecValidator{}, KeyMaterialValidator{}, failing}}.Report(input)
+
+		require.Len(t, report.Findings, 3)
+		assert.Equal(t, "/id", report.Findings[0].Pointer)
+		assert.Equal(t, "/verificationMethod/0", report.Findings[1].Pointer)
+		assertIsError(t, ErrInvalidKeyMaterial, report.Findings[1].Code)
+		assert.Equal(t, "", report.Findings[2].Pointer)
+		assert.EqualError(t, report.Findings[2].Code, "failed")
+	})
 	t.Run("returns second", func(t *testing.T) {
 		v1 := W3CSpecValidator{}
 		v2 := funcValidator{fn: func(_ Document) error {
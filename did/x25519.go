@@ -0,0 +1,81 @@
+package did
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ssi "github.com/nuts-foundation/go-did"
+)
+
+// curve25519P is the prime 2^255 - 19 of the field underlying both Ed25519 and X25519.
+var curve25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// NewX25519KeyAgreementMethod derives an X25519 key agreement verification method from an Ed25519 verification method,
+// by converting the Ed25519 public key to its birationally equivalent X25519 (Montgomery) form (RFC 7748, section 4.1).
+// The type of the resulting verification method follows the type of the source:
+//   - Ed25519VerificationKey2018 results in X25519KeyAgreementKey2019
+//   - Ed25519VerificationKey2020 results in X25519KeyAgreementKey2020
+//   - Multikey and JsonWebKey2020 keep their type
+//
+// The controller is copied from the source. Add the result to the document using AddKeyAgreement.
+func NewX25519KeyAgreementMethod(id DIDURL, source VerificationMethod) (*VerificationMethod, error) {
+	var keyType ssi.KeyType
+	switch source.Type {
+	case ssi.ED25519VerificationKey2018:
+		keyType = ssi.X25519KeyAgreementKey2019
+	case ssi.ED25519VerificationKey2020:
+		keyType = ssi.X25519KeyAgreementKey2020
+	case ssi.Multikey, ssi.JsonWebKey2020:
+		keyType = source.Type
+	default:
+		return nil, fmt.Errorf("can't derive X25519 key from verification method type: %s", source.Type)
+	}
+	publicKey, err := source.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	ed25519Key, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("verification method does not contain an Ed25519 key (%T)", publicKey)
+	}
+	x25519Key, err := ed25519PublicKeyToX25519(ed25519Key)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerificationMethod(id, keyType, source.Controller, x25519Key)
+}
+
+// ed25519PublicKeyToX25519 converts an Ed25519 public key to X25519 using u = (1 + y) / (1 - y) mod p,
+// where y is the Edwards y-coordinate encoded in the Ed25519 public key.
+func ed25519PublicKeyToX25519(key ed25519.PublicKey) (*ecdh.PublicKey, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 public key size: %d", len(key))
+	}
+	// The key is the little-endian y-coordinate, with the most significant bit holding the sign of x.
+	yBytes := make([]byte, len(key))
+	for i := range key {
+		yBytes[len(key)-1-i] = key[i]
+	}
+	yBytes[0] &= 0x7f
+	y := new(big.Int).SetBytes(yBytes)
+	if y.Cmp(curve25519P) >= 0 {
+		return nil, errors.New("invalid Ed25519 public key: y-coordinate out of range")
+	}
+	denominator := new(big.Int).Sub(big.NewInt(1), y)
+	denominator.Mod(denominator, curve25519P)
+	if denominator.Sign() == 0 {
+		return nil, errors.New("invalid Ed25519 public key: identity point")
+	}
+	u := new(big.Int).Add(big.NewInt(1), y)
+	u.Mul(u, denominator.ModInverse(denominator, curve25519P))
+	u.Mod(u, curve25519P)
+
+	uBytes := u.FillBytes(make([]byte, 32))
+	for i, j := 0, len(uBytes)-1; i < j; i, j = i+1, j-1 {
+		uBytes[i], uBytes[j] = uBytes[j], uBytes[i]
+	}
+	return ecdh.X25519().NewPublicKey(uBytes)
+}
@@ -0,0 +1,129 @@
+package did
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/json"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewX25519KeyAgreementMethod(t *testing.T) {
+	controller := MustParseDID("did:example:123")
+	id := MustParseDIDURL("did:example:123#key-agreement")
+	edPublicKey, edPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	// The X25519 private key is derived from the Ed25519 seed the same way Ed25519 derives its scalar (RFC 8032, section 5.1.5),
+	// so its public key must match the converted Ed25519 public key.
+	digest := sha512.Sum512(edPrivateKey.Seed())
+	xPrivateKey, err := ecdh.X25519().NewPrivateKey(digest[:32])
+	require.NoError(t, err)
+	expected := xPrivateKey.PublicKey()
+
+	testCases := []struct {
+		sourceType   ssi.KeyType
+		expectedType ssi.KeyType
+	}{
+		{sourceType: ssi.ED25519VerificationKey2018, expectedType: ssi.X25519KeyAgreementKey2019},
+		{sourceType: ssi.ED25519VerificationKey2020, expectedType: ssi.X25519KeyAgreementKey2020},
+		{sourceType: ssi.Multikey, expectedType: ssi.Multikey},
+		{sourceType: ssi.JsonWebKey2020, expectedType: ssi.JsonWebKey2020},
+	}
+	for _, testCase := range testCases {
+		t.Run(string(testCase.sourceType), func(t *testing.T) {
+			source, err := NewVerificationMethod(MustParseDIDURL("did:example:123#key-1"), testCase.sourceType, controller, edPublicKey)
+			require.NoError(t, err)
+
+			actual, err := NewX25519KeyAgreementMethod(id, *source)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedType, actual.Type)
+			assert.Equal(t, id, actual.ID)
+			assert.Equal(t, controller, actual.Controller)
+			publicKey, err := actual.PublicKey()
+			require.NoError(t, err)
+			assert.True(t, expected.Equal(publicKey))
+		})
+	}
+	t.Run("unsupported type", func(t *testing.T) {
+		source := VerificationMethod{Type: ssi.ECDSASECP256K1VerificationKey2019}
+		_, err := NewX25519KeyAgreementMethod(id, source)
+		assert.EqualError(t, err, "can't derive X25519 key from verification method type: EcdsaSecp256k1VerificationKey2019")
+	})
+	t.Run("not an Ed25519 key", func(t *testing.T) {
+		source, _ := NewVerificationMethod(id, ssi.Multikey, controller, expected)
+		_, err := NewX25519KeyAgreementMethod(id, *source)
+		assert.EqualError(t, err, "verification method does not contain an Ed25519 key (*ecdh.PublicKey)")
+	})
+	t.Run("invalid Ed25519 key", func(t *testing.T) {
+		// y = 1 is the identity point, which has no X25519 equivalent
+		identity := make([]byte, ed25519.PublicKeySize)
+		identity[0] = 1
+		source, _ := NewVerificationMethod(id, ssi.ED25519VerificationKey2020, controller, ed25519.PublicKey(identity))
+		_, err := NewX25519KeyAgreementMethod(id, *source)
+		assert.EqualError(t, err, "invalid Ed25519 public key: identity point")
+	})
+}
+
+func TestVerificationMethod_X25519(t *testing.T) {
+	id := MustParseDIDURL("did:example:123#key-agreement")
+	privateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	t.Run("X25519KeyAgreementKey2019", func(t *testing.T) {
+		vm, err := NewVerificationMethod(id, ssi.X25519KeyAgreementKey2019, id.DID, privateKey.PublicKey())
+		require.NoError(t, err)
+		assert.NotEmpty(t, vm.PublicKeyBase58)
+		assert.Empty(t, vm.PublicKeyMultibase)
+
+		publicKey, err := vm.PublicKey()
+
+		require.NoError(t, err)
+		assert.Equal(t, privateKey.PublicKey(), publicKey)
+	})
+	t.Run("X25519KeyAgreementKey2020", func(t *testing.T) {
+		vm, err := NewVerificationMethod(id, ssi.X25519KeyAgreementKey2020, id.DID, privateKey.PublicKey())
+		require.NoError(t, err)
+		assert.Equal(t, "z6LS", vm.PublicKeyMultibase[:4])
+
+		publicKey, err := vm.PublicKey()
+
+		require.NoError(t, err)
+		assert.Equal(t, privateKey.PublicKey(), publicKey)
+	})
+	t.Run("X25519KeyAgreementKey2020 from JSON", func(t *testing.T) {
+		// Example from https://w3c-ccg.github.io/di-x25519-2020/
+		var vm VerificationMethod
+		err := json.Unmarshal([]byte(`{
+			"id": "did:example:123#zC1Rnuvw9rVa6E5TKF4uQVRuQuaCpVgB81Um2u17Fu7UK",
+			"type": "X25519KeyAgreementKey2020",
+			"controller": "did:example:123",
+			"publicKeyMultibase": "z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc"
+		}`), &vm)
+		require.NoError(t, err)
+
+		publicKey, err := vm.PublicKey()
+
+		require.NoError(t, err)
+		assert.IsType(t, &ecdh.PublicKey{}, publicKey)
+	})
+	t.Run("X25519KeyAgreementKey2020 with Ed25519 multicodec", func(t *testing.T) {
+		vm := VerificationMethod{
+			Type:               ssi.X25519KeyAgreementKey2020,
+			PublicKeyMultibase: "z6MkmM42vxfqZQsv4ehtTjFFxQ4sQKS2w6WR7emozFAn5cxu",
+		}
+		_, err := vm.PublicKey()
+		assert.EqualError(t, err, "expected x25519-pub multicodec, got ed25519-pub")
+	})
+	t.Run("X25519KeyAgreementKey2019 - missing key material", func(t *testing.T) {
+		_, err := VerificationMethod{Type: ssi.X25519KeyAgreementKey2019}.PublicKey()
+		assert.EqualError(t, err, "expected either publicKeyMultibase or publicKeyBase58 to be set")
+	})
+	t.Run("wrong key type", func(t *testing.T) {
+		edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+		_, err := NewVerificationMethod(id, ssi.X25519KeyAgreementKey2020, id.DID, edKey)
+		assert.EqualError(t, err, "wrong key type")
+	})
+}
@@ -7,6 +7,7 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/lestrrat-go/jwx/v3 v3.2.0
 	github.com/multiformats/go-multibase v0.3.0
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2
 	github.com/shengdoushi/base58 v1.0.0
 	github.com/stretchr/testify v1.12.0
 )
@@ -26,5 +27,6 @@ require (
 	github.com/valyala/fastjson v1.6.10 // indirect
 	golang.org/x/crypto v0.54.0 // indirect
 	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,63 @@
+package clone
+
+import "reflect"
+
+// Of returns a deep copy of the given value: maps, slices, pointers and interface values are copied recursively.
+// Exported struct fields are copied recursively as well, unexported struct fields are copied as-is.
+func Of[T any](value T) T {
+	source := reflect.ValueOf(&value).Elem()
+	target := reflect.New(source.Type()).Elem()
+	copyValue(target, source)
+	return *target.Addr().Interface().(*T)
+}
+
+func copyValue(target reflect.Value, source reflect.Value) {
+	switch source.Kind() {
+	case reflect.Pointer:
+		if source.IsNil() {
+			return
+		}
+		target.Set(reflect.New(source.Type().Elem()))
+		copyValue(target.Elem(), source.Elem())
+	case reflect.Interface:
+		if source.IsNil() {
+			return
+		}
+		elem := reflect.New(source.Elem().Type()).Elem()
+		copyValue(elem, source.Elem())
+		target.Set(elem)
+	case reflect.Map:
+		if source.IsNil() {
+			return
+		}
+		target.Set(reflect.MakeMapWithSize(source.Type(), source.Len()))
+		iter := source.MapRange()
+		for iter.Next() {
+			elem := reflect.New(source.Type().Elem()).Elem()
+			copyValue(elem, iter.Value())
+			target.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.Slice:
+		if source.IsNil() {
+			return
+		}
+		target.Set(reflect.MakeSlice(source.Type(), source.Len(), source.Len()))
+		for i := 0; i < source.Len(); i++ {
+			copyValue(target.Index(i), source.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < source.Len(); i++ {
+			copyValue(target.Index(i), source.Index(i))
+		}
+	case reflect.Struct:
+		// Copy the whole struct first, so unexported fields are retained
+		target.Set(source)
+		for i := 0; i < source.NumField(); i++ {
+			if target.Field(i).CanSet() {
+				copyValue(target.Field(i), source.Field(i))
+			}
+		}
+	default:
+		target.Set(source)
+	}
+}
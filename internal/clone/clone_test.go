@@ -0,0 +1,48 @@
+package clone
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	t.Run("nested maps and slices", func(t *testing.T) {
+		input := map[string]interface{}{
+			"name":  "test",
+			"list":  []interface{}{"a", map[string]interface{}{"b": 1.0}},
+			"inner": map[string]interface{}{"c": true},
+		}
+		actual := Of(input)
+		assert.Equal(t, input, actual)
+
+		actual["list"].([]interface{})[1].(map[string]interface{})["b"] = 2.0
+		actual["inner"].(map[string]interface{})["c"] = false
+		assert.Equal(t, 1.0, input["list"].([]interface{})[1].(map[string]interface{})["b"])
+		assert.Equal(t, true, input["inner"].(map[string]interface{})["c"])
+	})
+	t.Run("struct with pointers", func(t *testing.T) {
+		type test struct {
+			Time   *time.Time
+			Query  url.Values
+			hidden string
+		}
+		now := time.Now()
+		input := &test{Time: &now, Query: url.Values{"a": []string{"b"}}, hidden: "value"}
+		actual := Of(input)
+		assert.Equal(t, input, actual)
+		assert.NotSame(t, input, actual)
+		assert.NotSame(t, input.Time, actual.Time)
+		assert.Equal(t, "value", actual.hidden)
+
+		actual.Query.Add("a", "c")
+		assert.Len(t, input.Query["a"], 1)
+	})
+	t.Run("nil values are retained", func(t *testing.T) {
+		var input []string
+		assert.Nil(t, Of(input))
+		assert.Nil(t, Of[interface{}](nil))
+	})
+}
@@ -0,0 +1,245 @@
+package keystore
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwe"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/nuts-foundation/go-did/did"
+)
+
+var _ Store = (*FileStore)(nil)
+
+const fileExtension = ".jwe"
+
+// defaultPBES2Count is the default number of PBKDF2 iterations used to derive the key encryption key from the password.
+// It follows the OWASP recommendation for PBKDF2-HMAC-SHA512. It is also the minimum number of iterations Signer accepts when decrypting,
+// so keys written with the default (or fewer iterations) can always be read back.
+const defaultPBES2Count = 210000
+
+// FileStore is a Store that keeps every key in its own file in a directory.
+// Keys are stored as JWK, encrypted with a password as JWE using PBES2-HS512+A256KW and A256GCM.
+// The ID of a key is stored in the (unencrypted) `kid` header, so keys can be listed without the password.
+// Note that storing secp256k1 keys requires the jwx_es256k build tag.
+type FileStore struct {
+	dir        string
+	password   []byte
+	pbes2Count int
+	mux        sync.RWMutex
+}
+
+// FileStoreOption is an option for NewFileStore.
+type FileStoreOption func(store *FileStore)
+
+// WithPBES2Count sets the number of PBKDF2 iterations used to derive the key encryption key from the password (default 210,000).
+// Keys encrypted with more iterations than the default can only be read by stores configured with at least that number of iterations.
+func WithPBES2Count(count int) FileStoreOption {
+	return func(store *FileStore) {
+		store.pbes2Count = count
+	}
+}
+
+// NewFileStore creates a FileStore that keeps its keys in the given directory, creating it if it doesn't exist.
+// The keys are encrypted with the given password.
+func NewFileStore(dir string, password []byte, options ...FileStoreOption) (*FileStore, error) {
+	if len(password) == 0 {
+		return nil, errors.New("password is required")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create key store directory: %w", err)
+	}
+	result := &FileStore{
+		dir:        dir,
+		password:   password,
+		pbes2Count: defaultPBES2Count,
+	}
+	for _, option := range options {
+		option(result)
+	}
+	return result, nil
+}
+
+func (f *FileStore) Generate(ctx context.Context, id did.DIDURL, keyType KeyType) (crypto.PublicKey, error) {
+	signer, err := generateKey(keyType)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Import(ctx, id, signer); err != nil {
+		return nil, err
+	}
+	return signer.Public(), nil
+}
+
+func (f *FileStore) Import(_ context.Context, id did.DIDURL, key crypto.Signer) error {
+	if err := validateSigner(key); err != nil {
+		return err
+	}
+	keyAsJWK, err := jwk.Import(key)
+	if err != nil {
+		return fmt.Errorf("unable to convert key to JWK: %w", err)
+	}
+	if isPrivate, _ := jwk.IsPrivateKey(keyAsJWK); !isPrivate {
+		return errors.New("key is not a private key")
+	}
+	plaintext, err := json.Marshal(keyAsJWK)
+	if err != nil {
+		return err
+	}
+	headers := jwe.NewHeaders()
+	if err := headers.Set(jwk.KeyIDKey, id.String()); err != nil {
+		return err
+	}
+	ciphertext, err := jwe.Encrypt(plaintext,
+		jwe.WithKey(jwa.PBES2_HS512_A256KW(), f.password),
+		jwe.WithContentEncryption(jwa.A256GCM()),
+		jwe.WithProtectedHeaders(headers),
+		jwe.WithPBES2Count(f.pbes2Count),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt key: %w", err)
+	}
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	file, err := os.OpenFile(f.path(id), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if errors.Is(err, fs.ErrExist) {
+		return ErrKeyExists
+	} else if err != nil {
+		return fmt.Errorf("unable to store key: %w", err)
+	}
+	_, err = file.Write(ciphertext)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return fmt.Errorf("unable to store key: %w", err)
+	}
+	return nil
+}
+
+func (f *FileStore) List(_ context.Context) ([]did.DIDURL, error) {
+	f.mux.RLock()
+	defer f.mux.RUnlock()
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list keys: %w", err)
+	}
+	result := make([]did.DIDURL, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileExtension) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(f.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read key file %s: %w", entry.Name(), err)
+		}
+		id, err := keyIDOf(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key file %s: %w", entry.Name(), err)
+		}
+		result = append(result, *id)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result, nil
+}
+
+func (f *FileStore) Signer(_ context.Context, id did.DIDURL) (crypto.Signer, error) {
+	f.mux.RLock()
+	data, err := os.ReadFile(f.path(id))
+	f.mux.RUnlock()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrKeyNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read key: %w", err)
+	}
+	message := jwe.NewMessage()
+	plaintext, err := jwe.Decrypt(data,
+		jwe.WithKey(jwa.PBES2_HS512_A256KW(), f.password),
+		jwe.WithMaxPBES2Count(max(f.pbes2Count, defaultPBES2Count)),
+		jwe.WithMessage(message),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt key: %w", err)
+	}
+	// The file name is derived from the ID, so check the (authenticated) kid header to detect key files that were copied or renamed
+	if storedID, err := keyIDOfMessage(message); err != nil {
+		return nil, fmt.Errorf("invalid key file: %w", err)
+	} else if storedID.String() != id.String() {
+		return nil, fmt.Errorf("key file contains key %s instead of %s", storedID, id)
+	}
+	keyAsJWK, err := jwk.ParseKey(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse key: %w", err)
+	}
+	var key any
+	if err := jwk.Export(keyAsJWK, &key); err != nil {
+		return nil, fmt.Errorf("unable to parse key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("stored key is not a signing key (%T)", key)
+	}
+	return signer, nil
+}
+
+func (f *FileStore) Sign(ctx context.Context, id did.DIDURL, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	signer, err := f.Signer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(rand.Reader, digest, opts)
+}
+
+func (f *FileStore) Delete(_ context.Context, id did.DIDURL) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	err := os.Remove(f.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrKeyNotFound
+	} else if err != nil {
+		return fmt.Errorf("unable to delete key: %w", err)
+	}
+	return nil
+}
+
+// path returns the file path for the key with the given ID.
+// The file name is derived from a hash of the ID, since IDs may contain characters that aren't allowed in file names.
+func (f *FileStore) path(id did.DIDURL) string {
+	hash := sha256.Sum256([]byte(id.String()))
+	return filepath.Join(f.dir, hex.EncodeToString(hash[:])+fileExtension)
+}
+
+// keyIDOf returns the key ID from the `kid` header of the given JWE, without decrypting it.
+func keyIDOf(data []byte) (*did.DIDURL, error) {
+	message, err := jwe.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return keyIDOfMessage(message)
+}
+
+// keyIDOfMessage returns the key ID from the `kid` header of the given JWE message.
+func keyIDOfMessage(message *jwe.Message) (*did.DIDURL, error) {
+	var keyID string
+	if err := message.ProtectedHeaders().Get(jwk.KeyIDKey, &keyID); err != nil {
+		return nil, fmt.Errorf("missing kid header: %w", err)
+	}
+	return did.ParseDIDURL(keyID)
+}
@@ -0,0 +1,116 @@
+package keystore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	testStore(t, func(t *testing.T) Store {
+		store, err := NewFileStore(t.TempDir(), []byte("password"))
+		require.NoError(t, err)
+		return store
+	})
+
+	ctx := context.Background()
+	keyID := did.MustParseDIDURL("did:example:123#key-1")
+	t.Run("keys are persisted and encrypted", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir, []byte("password"))
+		publicKey, err := store.Generate(ctx, keyID, P256)
+		require.NoError(t, err)
+
+		files, _ := filepath.Glob(filepath.Join(dir, "*"+fileExtension))
+		require.Len(t, files, 1)
+		info, _ := os.Stat(files[0])
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		data, _ := os.ReadFile(files[0])
+		assert.NotContains(t, string(data), `"d"`)
+
+		reopened, _ := NewFileStore(dir, []byte("password"))
+		actual, err := PublicKey(ctx, reopened, keyID)
+		require.NoError(t, err)
+		assert.Equal(t, publicKey, actual)
+	})
+	t.Run("wrong password", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir, []byte("password"))
+		_, _ = store.Generate(ctx, keyID, Ed25519)
+
+		other, _ := NewFileStore(dir, []byte("other"))
+		_, err := other.Signer(ctx, keyID)
+
+		assert.ErrorContains(t, err, "unable to decrypt key")
+		// listing doesn't require the password
+		ids, err := other.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []did.DIDURL{keyID}, ids)
+	})
+	t.Run("WithPBES2Count", func(t *testing.T) {
+		store, _ := NewFileStore(t.TempDir(), []byte("password"), WithPBES2Count(20000))
+		_, err := store.Generate(ctx, keyID, Ed25519)
+		require.NoError(t, err)
+
+		_, err = store.Signer(ctx, keyID)
+
+		assert.NoError(t, err)
+	})
+	t.Run("keys written with the default count can be read by stores with a lower count", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir, []byte("password"))
+		_, err := store.Generate(ctx, keyID, Ed25519)
+		require.NoError(t, err)
+
+		other, _ := NewFileStore(dir, []byte("password"), WithPBES2Count(20000))
+		_, err = other.Signer(ctx, keyID)
+
+		assert.NoError(t, err)
+	})
+	t.Run("ignores other files", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir, []byte("password"))
+		_ = os.WriteFile(filepath.Join(dir, "README"), []byte("hello"), 0600)
+		_ = os.Mkdir(filepath.Join(dir, "sub"+fileExtension), 0700)
+
+		ids, err := store.List(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+	t.Run("invalid key file", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir, []byte("password"))
+		_ = os.WriteFile(filepath.Join(dir, "invalid"+fileExtension), []byte("hello"), 0600)
+
+		_, err := store.List(ctx)
+
+		assert.ErrorContains(t, err, "invalid key file invalid.jwe")
+	})
+	t.Run("key file of another key", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir, []byte("password"))
+		otherKeyID := did.MustParseDIDURL("did:example:456#key-1")
+		_, _ = store.Generate(ctx, otherKeyID, Ed25519)
+		require.NoError(t, os.Rename(store.path(otherKeyID), store.path(keyID)))
+
+		_, err := store.Signer(ctx, keyID)
+
+		assert.EqualError(t, err, "key file contains key did:example:456#key-1 instead of did:example:123#key-1")
+	})
+	t.Run("password is required", func(t *testing.T) {
+		_, err := NewFileStore(t.TempDir(), nil)
+		assert.EqualError(t, err, "password is required")
+	})
+	t.Run("creates directory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "keys")
+		_, err := NewFileStore(dir, []byte("password"))
+		require.NoError(t, err)
+		assert.DirExists(t, dir)
+	})
+}
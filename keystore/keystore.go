@@ -0,0 +1,122 @@
+// Package keystore provides storage for private keys, identified by the ID of the verification method they belong to.
+// It contains an in-memory store (NewMemoryStore) and a store that keeps keys in encrypted files (NewFileStore).
+package keystore
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+)
+
+// ErrKeyNotFound is returned when the requested key does not exist in the store.
+var ErrKeyNotFound = errors.New("key not found")
+
+// ErrKeyExists is returned when a key is generated or imported with an ID that is already in use.
+var ErrKeyExists = errors.New("key already exists")
+
+// KeyType is the type of key to generate.
+type KeyType string
+
+const (
+	// Ed25519 generates an Ed25519 key.
+	Ed25519 KeyType = "Ed25519"
+	// P256 generates an ECDSA key on the NIST P-256 curve.
+	P256 KeyType = "P-256"
+	// P384 generates an ECDSA key on the NIST P-384 curve.
+	P384 KeyType = "P-384"
+	// Secp256k1 generates an ECDSA key on the secp256k1 curve.
+	Secp256k1 KeyType = "secp256k1"
+	// RSA2048 generates a 2048-bit RSA key.
+	RSA2048 KeyType = "RSA-2048"
+)
+
+// Store stores private keys, identified by the ID of the verification method they belong to.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Generate generates a new key of the given type, stores it under the given ID and returns its public key.
+	// It returns ErrKeyExists if a key with the given ID already exists.
+	Generate(ctx context.Context, id did.DIDURL, keyType KeyType) (crypto.PublicKey, error)
+	// Import stores the given private key under the given ID.
+	// It returns ErrKeyExists if a key with the given ID already exists.
+	Import(ctx context.Context, id did.DIDURL, key crypto.Signer) error
+	// List returns the IDs of the stored keys.
+	List(ctx context.Context) ([]did.DIDURL, error)
+	// Signer returns a crypto.Signer for the key with the given ID, or ErrKeyNotFound if it does not exist.
+	Signer(ctx context.Context, id did.DIDURL) (crypto.Signer, error)
+	// Sign signs the digest with the key with the given ID, or returns ErrKeyNotFound if it does not exist.
+	// The digest and opts are passed to crypto.Signer.Sign.
+	Sign(ctx context.Context, id did.DIDURL, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+	// Delete removes the key with the given ID, or returns ErrKeyNotFound if it does not exist.
+	Delete(ctx context.Context, id did.DIDURL) error
+}
+
+// PublicKey returns the public key of the key with the given ID.
+func PublicKey(ctx context.Context, store Store, id did.DIDURL) (crypto.PublicKey, error) {
+	signer, err := store.Signer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Public(), nil
+}
+
+// VerificationMethod creates a verification method of the given type for the key with the given ID.
+// The ID of the key is used as ID of the verification method.
+func VerificationMethod(ctx context.Context, store Store, id did.DIDURL, keyType ssi.KeyType, controller did.DID) (*did.VerificationMethod, error) {
+	publicKey, err := PublicKey(ctx, store, id)
+	if err != nil {
+		return nil, err
+	}
+	return did.NewVerificationMethod(id, keyType, controller, publicKey)
+}
+
+// JWTSigner creates a vc.JWTSigner that signs with the key of the given verification method (see vc.NewJWTSigner).
+func JWTSigner(ctx context.Context, store Store, method did.VerificationMethod) (vc.JWTSigner, error) {
+	signer, err := store.Signer(ctx, method.ID)
+	if err != nil {
+		return nil, err
+	}
+	return vc.NewJWTSigner(signer, method)
+}
+
+func generateKey(keyType KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case Ed25519:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		return privateKey, err
+	case P256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case P384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case Secp256k1:
+		privateKey, err := secp256k1.GeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		return privateKey.ToECDSA(), nil
+	case RSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	}
+	return nil, fmt.Errorf("unsupported key type: %s", keyType)
+}
+
+// validateSigner checks that the given key can be stored, so Signer doesn't return an unusable key later.
+func validateSigner(key crypto.Signer) error {
+	if key == nil {
+		return errors.New("key is nil")
+	}
+	if key.Public() == nil {
+		return errors.New("key has no public key")
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package keystore
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jws"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStore runs the tests every Store implementation must pass.
+func testStore(t *testing.T, createStore func(t *testing.T) Store) {
+	ctx := context.Background()
+	keyID := did.MustParseDIDURL("did:example:123#key-1")
+	t.Run("generate", func(t *testing.T) {
+		keyTypes := map[KeyType]func(t *testing.T, key crypto.PublicKey){
+			Ed25519: func(t *testing.T, key crypto.PublicKey) {
+				assert.IsType(t, ed25519.PublicKey{}, key)
+			},
+			P256: func(t *testing.T, key crypto.PublicKey) {
+				assert.Equal(t, elliptic.P256(), key.(*ecdsa.PublicKey).Curve)
+			},
+			P384: func(t *testing.T, key crypto.PublicKey) {
+				assert.Equal(t, elliptic.P384(), key.(*ecdsa.PublicKey).Curve)
+			},
+			Secp256k1: func(t *testing.T, key crypto.PublicKey) {
+				assert.Equal(t, "secp256k1", key.(*ecdsa.PublicKey).Curve.Params().Name)
+			},
+			RSA2048: func(t *testing.T, key crypto.PublicKey) {
+				assert.Equal(t, 2048, key.(*rsa.PublicKey).N.BitLen())
+			},
+		}
+		for keyType, assertKey := range keyTypes {
+			t.Run(string(keyType), func(t *testing.T) {
+				store := createStore(t)
+
+				publicKey, err := store.Generate(ctx, keyID, keyType)
+
+				require.NoError(t, err)
+				assertKey(t, publicKey)
+				signer, err := store.Signer(ctx, keyID)
+				require.NoError(t, err)
+				assert.Equal(t, publicKey, signer.Public())
+			})
+		}
+		t.Run("unsupported key type", func(t *testing.T) {
+			_, err := createStore(t).Generate(ctx, keyID, "foo")
+			assert.EqualError(t, err, "unsupported key type: foo")
+		})
+		t.Run("already exists", func(t *testing.T) {
+			store := createStore(t)
+			_, err := store.Generate(ctx, keyID, P256)
+			require.NoError(t, err)
+
+			_, err = store.Generate(ctx, keyID, P256)
+
+			assert.ErrorIs(t, err, ErrKeyExists)
+		})
+	})
+	t.Run("import", func(t *testing.T) {
+		store := createStore(t)
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+		err := store.Import(ctx, keyID, privateKey)
+
+		require.NoError(t, err)
+		publicKey, err := PublicKey(ctx, store, keyID)
+		require.NoError(t, err)
+		assert.True(t, privateKey.PublicKey.Equal(publicKey))
+		assert.ErrorIs(t, store.Import(ctx, keyID, privateKey), ErrKeyExists)
+	})
+	t.Run("import - invalid key", func(t *testing.T) {
+		store := createStore(t)
+
+		assert.EqualError(t, store.Import(ctx, keyID, nil), "key is nil")
+		assert.EqualError(t, store.Import(ctx, keyID, noPublicKeySigner{}), "key has no public key")
+		_, err := store.Signer(ctx, keyID)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+	t.Run("list", func(t *testing.T) {
+		store := createStore(t)
+		keyID2 := did.MustParseDIDURL("did:example:456#key-1")
+		_, _ = store.Generate(ctx, keyID2, Ed25519)
+		_, _ = store.Generate(ctx, keyID, Ed25519)
+
+		ids, err := store.List(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []did.DIDURL{keyID, keyID2}, ids)
+	})
+	t.Run("list - empty", func(t *testing.T) {
+		ids, err := createStore(t).List(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+	t.Run("sign", func(t *testing.T) {
+		store := createStore(t)
+		publicKey, _ := store.Generate(ctx, keyID, P256)
+		digest := sha256.Sum256([]byte("hello"))
+
+		signature, err := store.Sign(ctx, keyID, digest[:], crypto.SHA256)
+
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(publicKey.(*ecdsa.PublicKey), digest[:], signature))
+	})
+	t.Run("sign - not found", func(t *testing.T) {
+		_, err := createStore(t).Sign(ctx, keyID, []byte("hello"), crypto.SHA256)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+	t.Run("signer - not found", func(t *testing.T) {
+		_, err := createStore(t).Signer(ctx, keyID)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+	t.Run("delete", func(t *testing.T) {
+		store := createStore(t)
+		_, _ = store.Generate(ctx, keyID, Ed25519)
+
+		err := store.Delete(ctx, keyID)
+
+		require.NoError(t, err)
+		_, err = store.Signer(ctx, keyID)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+		assert.ErrorIs(t, store.Delete(ctx, keyID), ErrKeyNotFound)
+	})
+	t.Run("JWT signing", func(t *testing.T) {
+		store := createStore(t)
+		_, err := store.Generate(ctx, keyID, Ed25519)
+		require.NoError(t, err)
+		method, err := VerificationMethod(ctx, store, keyID, ssi.Multikey, keyID.DID)
+		require.NoError(t, err)
+		signer, err := JWTSigner(ctx, store, *method)
+		require.NoError(t, err)
+
+		credential, err := vc.CreateJWTVerifiableCredential(ctx, vc.VerifiableCredential{
+			Context:           []ssi.URI{vc.VCContextV1URI()},
+			Type:              []ssi.URI{vc.VerifiableCredentialTypeV1URI()},
+			Issuer:            vc.NewIdentifier(keyID.DID.URI()),
+			IssuanceDate:      time.Now(),
+			CredentialSubject: []map[string]any{{"id": "did:example:subject"}},
+		}, signer)
+
+		require.NoError(t, err)
+		publicKey, _ := method.PublicKey()
+		_, err = jws.Verify([]byte(credential.Raw()), jws.WithKey(jwa.EdDSA(), publicKey))
+		assert.NoError(t, err)
+	})
+}
+
+func TestVerificationMethod(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	keyID := did.MustParseDIDURL("did:example:123#key-1")
+	t.Run("ok", func(t *testing.T) {
+		publicKey, _ := store.Generate(ctx, keyID, P256)
+
+		method, err := VerificationMethod(ctx, store, keyID, ssi.JsonWebKey2020, keyID.DID)
+
+		require.NoError(t, err)
+		assert.Equal(t, keyID, method.ID)
+		assert.Equal(t, keyID.DID, method.Controller)
+		actual, _ := method.PublicKey()
+		assert.Equal(t, publicKey, actual)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := VerificationMethod(ctx, store, did.MustParseDIDURL("did:example:123#other"), ssi.JsonWebKey2020, keyID.DID)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestJWTSigner(t *testing.T) {
+	ctx := context.Background()
+	t.Run("not found", func(t *testing.T) {
+		_, err := JWTSigner(ctx, NewMemoryStore(), did.VerificationMethod{ID: did.MustParseDIDURL("did:example:123#key-1")})
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+// noPublicKeySigner is a crypto.Signer that doesn't have a public key.
+type noPublicKeySigner struct{}
+
+func (noPublicKeySigner) Public() crypto.PublicKey {
+	return nil
+}
+
+func (noPublicKeySigner) Sign(_ io.Reader, _ []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
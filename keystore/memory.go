@@ -0,0 +1,94 @@
+package keystore
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"sort"
+	"sync"
+
+	"github.com/nuts-foundation/go-did/did"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore is a Store that keeps keys in memory. Keys are lost when the process exits.
+type MemoryStore struct {
+	mux  sync.RWMutex
+	keys map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	id     did.DIDURL
+	signer crypto.Signer
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{keys: map[string]memoryEntry{}}
+}
+
+func (m *MemoryStore) Generate(ctx context.Context, id did.DIDURL, keyType KeyType) (crypto.PublicKey, error) {
+	signer, err := generateKey(keyType)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Import(ctx, id, signer); err != nil {
+		return nil, err
+	}
+	return signer.Public(), nil
+}
+
+func (m *MemoryStore) Import(_ context.Context, id did.DIDURL, key crypto.Signer) error {
+	if err := validateSigner(key); err != nil {
+		return err
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if _, exists := m.keys[id.String()]; exists {
+		return ErrKeyExists
+	}
+	m.keys[id.String()] = memoryEntry{id: id, signer: key}
+	return nil
+}
+
+func (m *MemoryStore) List(_ context.Context) ([]did.DIDURL, error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	result := make([]did.DIDURL, 0, len(m.keys))
+	for _, entry := range m.keys {
+		result = append(result, entry.id)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result, nil
+}
+
+func (m *MemoryStore) Signer(_ context.Context, id did.DIDURL) (crypto.Signer, error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	entry, exists := m.keys[id.String()]
+	if !exists {
+		return nil, ErrKeyNotFound
+	}
+	return entry.signer, nil
+}
+
+func (m *MemoryStore) Sign(ctx context.Context, id did.DIDURL, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	signer, err := m.Signer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(rand.Reader, digest, opts)
+}
+
+func (m *MemoryStore) Delete(_ context.Context, id did.DIDURL) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if _, exists := m.keys[id.String()]; !exists {
+		return ErrKeyNotFound
+	}
+	delete(m.keys, id.String())
+	return nil
+}
@@ -0,0 +1,11 @@
+package keystore
+
+import (
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, func(t *testing.T) Store {
+		return NewMemoryStore()
+	})
+}
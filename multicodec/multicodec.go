@@ -0,0 +1,172 @@
+// Package multicodec implements multicodec prefixing of public keys, as used by the Multikey verification method type
+// (https://www.w3.org/TR/cid-1.0/#Multikey) and the did:key method.
+// See https://github.com/multiformats/multicodec for the codec table.
+package multicodec
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// Code is a multicodec code, identifying the type of the data it prefixes.
+type Code uint64
+
+const (
+	// Ed25519Pub identifies a raw Ed25519 public key (32 bytes).
+	Ed25519Pub Code = 0xed
+	// X25519Pub identifies a raw X25519 public key (32 bytes).
+	X25519Pub Code = 0xec
+	// Secp256k1Pub identifies a compressed secp256k1 public key (33 bytes).
+	Secp256k1Pub Code = 0xe7
+	// BLS12381G2Pub identifies a compressed BLS12-381 G2 public key (96 bytes).
+	BLS12381G2Pub Code = 0xeb
+	// P256Pub identifies a compressed P-256 public key (33 bytes).
+	P256Pub Code = 0x1200
+	// P384Pub identifies a compressed P-384 public key (49 bytes).
+	P384Pub Code = 0x1201
+)
+
+// ErrUnsupportedCode is returned when decoding data with a multicodec code that is not supported.
+var ErrUnsupportedCode = errors.New("unsupported multicodec")
+
+// ErrUnsupportedKey is returned when encoding a public key type that is not supported.
+var ErrUnsupportedKey = errors.New("unsupported public key type")
+
+// String returns the name of the code as listed in the multicodec table.
+func (c Code) String() string {
+	switch c {
+	case Ed25519Pub:
+		return "ed25519-pub"
+	case X25519Pub:
+		return "x25519-pub"
+	case Secp256k1Pub:
+		return "secp256k1-pub"
+	case BLS12381G2Pub:
+		return "bls12_381-g2-pub"
+	case P256Pub:
+		return "p256-pub"
+	case P384Pub:
+		return "p384-pub"
+	}
+	return fmt.Sprintf("0x%x", uint64(c))
+}
+
+// BLS12381G2PublicKey is a compressed BLS12-381 G2 public key.
+// The standard library has no BLS12-381 support, so the key is carried as its raw (96 bytes) encoding.
+type BLS12381G2PublicKey []byte
+
+const bls12381G2PublicKeySize = 96
+
+// Encode prefixes data with the unsigned varint encoding of the given code.
+func Encode(code Code, data []byte) []byte {
+	result := binary.AppendUvarint(nil, uint64(code))
+	return append(result, data...)
+}
+
+// Decode reads the varint-encoded code from the start of data and returns it together with the remaining bytes.
+func Decode(data []byte) (Code, []byte, error) {
+	code, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, errors.New("invalid multicodec prefix")
+	}
+	return Code(code), data[n:], nil
+}
+
+// EncodePublicKey encodes the given public key as multicodec-prefixed bytes.
+// Supported are ed25519.PublicKey, X25519 *ecdh.PublicKey, P-256/P-384/secp256k1 *ecdsa.PublicKey,
+// *secp256k1.PublicKey and BLS12381G2PublicKey. EC keys are encoded in compressed form.
+func EncodePublicKey(key crypto.PublicKey) ([]byte, error) {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		if len(k) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key size: %d", len(k))
+		}
+		return Encode(Ed25519Pub, k), nil
+	case *ecdh.PublicKey:
+		if k.Curve() != ecdh.X25519() {
+			return nil, fmt.Errorf("%w: ECDH key on curve %s", ErrUnsupportedKey, k.Curve())
+		}
+		return Encode(X25519Pub, k.Bytes()), nil
+	case *secp256k1.PublicKey:
+		return Encode(Secp256k1Pub, k.SerializeCompressed()), nil
+	case ecdsa.PublicKey:
+		return EncodePublicKey(&k)
+	case *ecdsa.PublicKey:
+		var code Code
+		switch k.Curve.Params().Name {
+		case elliptic.P256().Params().Name:
+			code = P256Pub
+		case elliptic.P384().Params().Name:
+			code = P384Pub
+		case secp256k1.S256().Params().Name:
+			code = Secp256k1Pub
+		default:
+			return nil, fmt.Errorf("%w: ECDSA key on curve %s", ErrUnsupportedKey, k.Curve.Params().Name)
+		}
+		if !k.Curve.IsOnCurve(k.X, k.Y) {
+			return nil, errors.New("invalid ECDSA public key: point is not on curve")
+		}
+		return Encode(code, elliptic.MarshalCompressed(k.Curve, k.X, k.Y)), nil
+	case BLS12381G2PublicKey:
+		if len(k) != bls12381G2PublicKeySize {
+			return nil, fmt.Errorf("invalid BLS12-381 G2 public key size: %d", len(k))
+		}
+		return Encode(BLS12381G2Pub, k), nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+}
+
+// DecodePublicKey decodes multicodec-prefixed public key bytes.
+// It returns an ed25519.PublicKey, *ecdh.PublicKey (X25519), *ecdsa.PublicKey (P-256, P-384 and secp256k1)
+// or BLS12381G2PublicKey, depending on the code.
+func DecodePublicKey(data []byte) (crypto.PublicKey, error) {
+	code, keyBytes, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case Ed25519Pub:
+		if len(keyBytes) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key size: %d", len(keyBytes))
+		}
+		return ed25519.PublicKey(keyBytes), nil
+	case X25519Pub:
+		key, err := ecdh.X25519().NewPublicKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid X25519 public key: %w", err)
+		}
+		return key, nil
+	case P256Pub:
+		return unmarshalCompressed(elliptic.P256(), keyBytes)
+	case P384Pub:
+		return unmarshalCompressed(elliptic.P384(), keyBytes)
+	case Secp256k1Pub:
+		key, err := secp256k1.ParsePubKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secp256k1 public key: %w", err)
+		}
+		return key.ToECDSA(), nil
+	case BLS12381G2Pub:
+		if len(keyBytes) != bls12381G2PublicKeySize {
+			return nil, fmt.Errorf("invalid BLS12-381 G2 public key size: %d", len(keyBytes))
+		}
+		return BLS12381G2PublicKey(keyBytes), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedCode, code)
+}
+
+func unmarshalCompressed(curve elliptic.Curve, data []byte) (*ecdsa.PublicKey, error) {
+	x, y := elliptic.UnmarshalCompressed(curve, data)
+	if x == nil {
+		return nil, fmt.Errorf("invalid %s public key", curve.Params().Name)
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
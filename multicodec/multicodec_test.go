@@ -0,0 +1,182 @@
+package multicodec
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	t.Run("single byte code", func(t *testing.T) {
+		assert.Equal(t, []byte{0xed, 0x01, 0xaa}, Encode(Ed25519Pub, []byte{0xaa}))
+	})
+	t.Run("two byte code", func(t *testing.T) {
+		assert.Equal(t, []byte{0x80, 0x24, 0xaa}, Encode(P256Pub, []byte{0xaa}))
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		code, data, err := Decode([]byte{0x81, 0x24, 0xaa})
+		require.NoError(t, err)
+		assert.Equal(t, P384Pub, code)
+		assert.Equal(t, []byte{0xaa}, data)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, _, err := Decode(nil)
+		assert.EqualError(t, err, "invalid multicodec prefix")
+	})
+	t.Run("truncated varint", func(t *testing.T) {
+		_, _, err := Decode([]byte{0x80})
+		assert.EqualError(t, err, "invalid multicodec prefix")
+	})
+}
+
+func TestCode_String(t *testing.T) {
+	assert.Equal(t, "ed25519-pub", Ed25519Pub.String())
+	assert.Equal(t, "p256-pub", P256Pub.String())
+	assert.Equal(t, "0x1234", Code(0x1234).String())
+}
+
+func TestEncodePublicKey(t *testing.T) {
+	t.Run("Ed25519", func(t *testing.T) {
+		// Test vector from https://www.w3.org/TR/cid-1.0/#Multikey
+		const expected = "z6MkmM42vxfqZQsv4ehtTjFFxQ4sQKS2w6WR7emozFAn5cxu"
+		_, decoded, _ := multibase.Decode(expected)
+		key, err := DecodePublicKey(decoded)
+		require.NoError(t, err)
+		require.IsType(t, ed25519.PublicKey{}, key)
+
+		encoded, err := EncodePublicKey(key)
+		require.NoError(t, err)
+		actual, _ := multibase.Encode(multibase.Base58BTC, encoded)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, []byte{0xed, 0x01}, encoded[:2])
+	})
+	t.Run("X25519", func(t *testing.T) {
+		privateKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+		encoded, err := EncodePublicKey(privateKey.PublicKey())
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xec, 0x01}, encoded[:2])
+		assert.Len(t, encoded, 34)
+	})
+	t.Run("P-256", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		encoded, err := EncodePublicKey(&privateKey.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x80, 0x24}, encoded[:2])
+		assert.Len(t, encoded, 2+33)
+		multibaseEncoded, _ := multibase.Encode(multibase.Base58BTC, encoded)
+		assert.Equal(t, "zDn", multibaseEncoded[:3])
+	})
+	t.Run("P-384", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		encoded, err := EncodePublicKey(privateKey.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x81, 0x24}, encoded[:2])
+		assert.Len(t, encoded, 2+49)
+	})
+	t.Run("secp256k1", func(t *testing.T) {
+		privateKey, _ := secp256k1.GeneratePrivateKey()
+		encoded, err := EncodePublicKey(privateKey.PubKey().ToECDSA())
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xe7, 0x01}, encoded[:2])
+		assert.Len(t, encoded, 2+33)
+
+		encodedNative, err := EncodePublicKey(privateKey.PubKey())
+		require.NoError(t, err)
+		assert.Equal(t, encoded, encodedNative)
+	})
+	t.Run("BLS12-381 G2", func(t *testing.T) {
+		encoded, err := EncodePublicKey(BLS12381G2PublicKey(make([]byte, 96)))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xeb, 0x01}, encoded[:2])
+	})
+	t.Run("invalid BLS12-381 G2 key size", func(t *testing.T) {
+		_, err := EncodePublicKey(BLS12381G2PublicKey(make([]byte, 10)))
+		assert.EqualError(t, err, "invalid BLS12-381 G2 public key size: 10")
+	})
+	t.Run("invalid Ed25519 key size", func(t *testing.T) {
+		_, err := EncodePublicKey(ed25519.PublicKey(make([]byte, 10)))
+		assert.EqualError(t, err, "invalid Ed25519 public key size: 10")
+	})
+	t.Run("unsupported ECDSA curve", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+		_, err := EncodePublicKey(&privateKey.PublicKey)
+		assert.ErrorIs(t, err, ErrUnsupportedKey)
+	})
+	t.Run("unsupported ECDH curve", func(t *testing.T) {
+		privateKey, _ := ecdh.P256().GenerateKey(rand.Reader)
+		_, err := EncodePublicKey(privateKey.PublicKey())
+		assert.ErrorIs(t, err, ErrUnsupportedKey)
+	})
+	t.Run("unsupported key type", func(t *testing.T) {
+		privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		_, err := EncodePublicKey(&privateKey.PublicKey)
+		assert.ErrorIs(t, err, ErrUnsupportedKey)
+		assert.ErrorContains(t, err, "*rsa.PublicKey")
+	})
+}
+
+func TestDecodePublicKey(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		ed25519Key, _, _ := ed25519.GenerateKey(rand.Reader)
+		x25519Key, _ := ecdh.X25519().GenerateKey(rand.Reader)
+		p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		secp256k1Key, _ := secp256k1.GeneratePrivateKey()
+		keys := map[string]any{
+			"Ed25519":      ed25519Key,
+			"X25519":       x25519Key.PublicKey(),
+			"P-256":        &p256Key.PublicKey,
+			"P-384":        &p384Key.PublicKey,
+			"secp256k1":    secp256k1Key.PubKey().ToECDSA(),
+			"BLS12-381 G2": BLS12381G2PublicKey(make([]byte, 96)),
+		}
+		for name, key := range keys {
+			t.Run(name, func(t *testing.T) {
+				encoded, err := EncodePublicKey(key)
+				require.NoError(t, err)
+
+				decoded, err := DecodePublicKey(encoded)
+
+				require.NoError(t, err)
+				assert.Equal(t, key, decoded)
+			})
+		}
+	})
+	t.Run("unsupported code", func(t *testing.T) {
+		_, err := DecodePublicKey(Encode(Code(0x1205), make([]byte, 32)))
+		assert.ErrorIs(t, err, ErrUnsupportedCode)
+		assert.EqualError(t, err, "unsupported multicodec: 0x1205")
+	})
+	t.Run("invalid Ed25519 key size", func(t *testing.T) {
+		_, err := DecodePublicKey(Encode(Ed25519Pub, make([]byte, 31)))
+		assert.EqualError(t, err, "invalid Ed25519 public key size: 31")
+	})
+	t.Run("invalid P-256 point", func(t *testing.T) {
+		_, err := DecodePublicKey(Encode(P256Pub, make([]byte, 33)))
+		assert.EqualError(t, err, "invalid P-256 public key")
+	})
+	t.Run("invalid secp256k1 point", func(t *testing.T) {
+		_, err := DecodePublicKey(Encode(Secp256k1Pub, make([]byte, 33)))
+		assert.ErrorContains(t, err, "invalid secp256k1 public key")
+	})
+	t.Run("invalid X25519 key size", func(t *testing.T) {
+		_, err := DecodePublicKey(Encode(X25519Pub, make([]byte, 31)))
+		assert.ErrorContains(t, err, "invalid X25519 public key")
+	})
+	t.Run("invalid prefix", func(t *testing.T) {
+		_, err := DecodePublicKey([]byte{0x80})
+		assert.EqualError(t, err, "invalid multicodec prefix")
+	})
+}
@@ -22,6 +22,30 @@ const ECDSASECP256K1VerificationKey2019 = KeyType("EcdsaSecp256k1VerificationKey
 // https://w3c-ccg.github.io/lds-rsa2018/
 const RSAVerificationKey2018 = KeyType("RsaVerificationKey2018")
 
+// ECDSASECP256R1VerificationKey2019 is the EcdsaSecp256r1VerificationKey2019 verification key type as specified here:
+// https://www.w3.org/TR/vc-di-ecdsa/
+// Its publicKeyMultibase contains the multicodec-prefixed, compressed P-256 public key.
+const ECDSASECP256R1VerificationKey2019 = KeyType("EcdsaSecp256r1VerificationKey2019")
+
+// JsonWebKey is the JsonWebKey verification method type, the successor of JsonWebKey2020, as specified here:
+// https://www.w3.org/TR/cid-1.0/#JsonWebKey
+const JsonWebKey = KeyType("JsonWebKey")
+
+// Multikey is the Multikey verification method type as specified here:
+// https://www.w3.org/TR/cid-1.0/#Multikey
+// Its publicKeyMultibase contains the multicodec-prefixed public key.
+const Multikey = KeyType("Multikey")
+
+// X25519KeyAgreementKey2019 is the X25519KeyAgreementKey2019 key agreement key type as specified here:
+// https://w3c-ccg.github.io/ld-cryptosuite-registry/#x25519keyagreementkey2019
+// Its publicKeyBase58 contains the raw X25519 public key.
+const X25519KeyAgreementKey2019 = KeyType("X25519KeyAgreementKey2019")
+
+// X25519KeyAgreementKey2020 is the X25519KeyAgreementKey2020 key agreement key type as specified here:
+// https://w3c-ccg.github.io/di-x25519-2020/
+// Its publicKeyMultibase contains the multicodec-prefixed X25519 public key.
+const X25519KeyAgreementKey2020 = KeyType("X25519KeyAgreementKey2020")
+
 type ProofType string
 
 // JsonWebSignature2020 is a Proof type.
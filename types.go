@@ -1,6 +1,8 @@
 package ssi
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding"
 	"encoding/json"
 	"fmt"
@@ -8,6 +10,11 @@ import (
 )
 
 var _ encoding.TextMarshaler = URI{}
+var _ encoding.TextUnmarshaler = &URI{}
+var _ encoding.BinaryMarshaler = URI{}
+var _ encoding.BinaryUnmarshaler = &URI{}
+var _ sql.Scanner = &URI{}
+var _ driver.Valuer = URI{}
 var _ json.Marshaler = URI{}
 var _ json.Unmarshaler = &URI{}
 var _ fmt.Stringer = URI{}
@@ -31,7 +38,12 @@ func (v *URI) UnmarshalJSON(bytes []byte) error {
 	if err := json.Unmarshal(bytes, &value); err != nil {
 		return err
 	}
-	parsedUrl, err := url.Parse(value)
+	return v.UnmarshalText([]byte(value))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (v *URI) UnmarshalText(text []byte) error {
+	parsedUrl, err := url.Parse(string(text))
 	if err != nil {
 		return fmt.Errorf("could not parse URI: %w", err)
 	}
@@ -39,6 +51,39 @@ func (v *URI) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler. The binary form is the same as the text form.
+func (v URI) MarshalBinary() ([]byte, error) {
+	return v.MarshalText()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The binary form is the same as the text form.
+func (v *URI) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalText(data)
+}
+
+// Scan implements sql.Scanner. It accepts a string or []byte which is parsed as UnmarshalText does; NULL yields an empty URI.
+func (v *URI) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*v = URI{}
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(value))
+	case []byte:
+		return v.UnmarshalText(value)
+	default:
+		return fmt.Errorf("unsupported type for URI: %T", src)
+	}
+}
+
+// Value implements driver.Valuer. It returns the URI as string, or NULL if the URI is empty.
+func (v URI) Value() (driver.Value, error) {
+	if v.String() == "" {
+		return nil, nil
+	}
+	return v.String(), nil
+}
+
 // ParseURI parses a raw URI. If it can't be parsed, an error is returned.
 func ParseURI(input string) (*URI, error) {
 	u, err := url.Parse(input)
@@ -1,6 +1,7 @@
 package ssi
 
 import (
+	"encoding/json"
 	"net/url"
 	"testing"
 
@@ -51,3 +52,71 @@ func TestURI_MarshalText(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []byte("http://test"), actual)
 }
+
+func TestURI_UnmarshalText(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var actual URI
+		err := actual.UnmarshalText([]byte("https://example.com/path"))
+		assert.NoError(t, err)
+		assert.Equal(t, MustParseURI("https://example.com/path"), actual)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var actual URI
+		err := actual.UnmarshalText([]byte("%"))
+		assert.ErrorContains(t, err, "could not parse URI")
+	})
+	t.Run("as JSON map key", func(t *testing.T) {
+		var actual map[URI]string
+		err := json.Unmarshal([]byte(`{"https://example.com": "value"}`), &actual)
+		assert.NoError(t, err)
+		assert.Equal(t, map[URI]string{MustParseURI("https://example.com"): "value"}, actual)
+	})
+}
+
+func TestURI_MarshalBinary(t *testing.T) {
+	expected := MustParseURI("https://example.com/path?query#fragment")
+	data, err := expected.MarshalBinary()
+	assert.NoError(t, err)
+
+	var actual URI
+	err = actual.UnmarshalBinary(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestURI_Scan(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		var actual URI
+		assert.NoError(t, actual.Scan("https://example.com"))
+		assert.Equal(t, MustParseURI("https://example.com"), actual)
+	})
+	t.Run("bytes", func(t *testing.T) {
+		var actual URI
+		assert.NoError(t, actual.Scan([]byte("https://example.com")))
+		assert.Equal(t, MustParseURI("https://example.com"), actual)
+	})
+	t.Run("NULL", func(t *testing.T) {
+		actual := MustParseURI("https://example.com")
+		assert.NoError(t, actual.Scan(nil))
+		assert.Equal(t, URI{}, actual)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var actual URI
+		assert.Error(t, actual.Scan("%"))
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		var actual URI
+		assert.EqualError(t, actual.Scan(1), "unsupported type for URI: int")
+	})
+}
+
+func TestURI_Value(t *testing.T) {
+	value, err := MustParseURI("https://example.com").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", value)
+
+	value, err = URI{}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
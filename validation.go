@@ -0,0 +1,97 @@
+package ssi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity indicates how serious a validation finding is.
+type Severity string
+
+const (
+	// SeverityError indicates the finding makes the validated object invalid.
+	SeverityError Severity = "error"
+	// SeverityWarning indicates the finding doesn't make the validated object invalid, but should be addressed (e.g. use of deprecated properties).
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationFinding is a single finding of a validation.
+type ValidationFinding struct {
+	// Pointer is the JSON pointer (RFC 6901) to the property the finding applies to, e.g. /verificationMethod/2/controller.
+	// It is empty if the finding applies to the whole object.
+	Pointer string
+	// Code identifies the kind of finding. It is (or wraps) one of the sentinel errors of the validator that reported it,
+	// so it can be tested using errors.Is.
+	Code error
+	// Severity indicates whether the finding is an error or a warning.
+	Severity Severity
+	// Message is a human-readable description of the finding.
+	Message string
+}
+
+// String returns the finding formatted as "<severity> at <pointer>: <message>".
+func (f ValidationFinding) String() string {
+	pointer := f.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Sprintf("%s at %s: %s", f.Severity, pointer, f.Message)
+}
+
+// ValidationReport contains all findings of a validation.
+type ValidationReport struct {
+	Findings []ValidationFinding
+}
+
+// Valid returns true if the report contains no findings with SeverityError.
+func (r ValidationReport) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+// Errors returns the findings with SeverityError.
+func (r ValidationReport) Errors() []ValidationFinding {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the findings with SeverityWarning.
+func (r ValidationReport) Warnings() []ValidationFinding {
+	return r.filter(SeverityWarning)
+}
+
+// AddError adds a finding with SeverityError to the report.
+func (r *ValidationReport) AddError(pointer string, code error, message string) {
+	r.Findings = append(r.Findings, ValidationFinding{Pointer: pointer, Code: code, Severity: SeverityError, Message: message})
+}
+
+// AddWarning adds a finding with SeverityWarning to the report.
+func (r *ValidationReport) AddWarning(pointer string, code error, message string) {
+	r.Findings = append(r.Findings, ValidationFinding{Pointer: pointer, Code: code, Severity: SeverityWarning, Message: message})
+}
+
+// Append adds the findings of the other report to this report.
+func (r *ValidationReport) Append(other ValidationReport) {
+	r.Findings = append(r.Findings, other.Findings...)
+}
+
+func (r ValidationReport) filter(severity Severity) []ValidationFinding {
+	var result []ValidationFinding
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			result = append(result, finding)
+		}
+	}
+	return result
+}
+
+// JSONPointer builds a JSON pointer (RFC 6901) from the given reference tokens, e.g. JSONPointer("verificationMethod", 2, "controller")
+// returns /verificationMethod/2/controller. Tokens are formatted using fmt.Sprint and escaped.
+func JSONPointer(tokens ...interface{}) string {
+	var builder strings.Builder
+	for _, token := range tokens {
+		builder.WriteString("/")
+		builder.WriteString(jsonPointerEscaper.Replace(fmt.Sprint(token)))
+	}
+	return builder.String()
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
@@ -0,0 +1,38 @@
+package ssi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPointer(t *testing.T) {
+	assert.Equal(t, "", JSONPointer())
+	assert.Equal(t, "/verificationMethod/2/controller", JSONPointer("verificationMethod", 2, "controller"))
+	assert.Equal(t, "/a~1b/m~0n", JSONPointer("a/b", "m~n"))
+}
+
+func TestValidationReport(t *testing.T) {
+	errFoo := errors.New("foo")
+	report := ValidationReport{}
+	assert.True(t, report.Valid())
+
+	report.AddWarning("/a", errFoo, "warning")
+	assert.True(t, report.Valid())
+	assert.Len(t, report.Warnings(), 1)
+	assert.Empty(t, report.Errors())
+
+	other := ValidationReport{}
+	other.AddError("/b/0", errFoo, "error")
+	report.Append(other)
+	assert.False(t, report.Valid())
+	assert.Len(t, report.Findings, 2)
+	assert.Equal(t, "/b/0", report.Errors()[0].Pointer)
+	assert.Equal(t, SeverityError, report.Errors()[0].Severity)
+}
+
+func TestValidationFinding_String(t *testing.T) {
+	assert.Equal(t, "error at /id: invalid ID", ValidationFinding{Pointer: "/id", Severity: SeverityError, Message: "invalid ID"}.String())
+	assert.Equal(t, "warning at /: deprecated", ValidationFinding{Severity: SeverityWarning, Message: "deprecated"}.String())
+}
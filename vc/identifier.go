@@ -0,0 +1,109 @@
+package vc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/internal/clone"
+)
+
+// IdentifierOrObject is a property that is either a URI (e.g. "did:example:123"),
+// or an object with an `id` and additional properties (e.g. {"id": "did:example:123", "name": "ACME"}).
+// It is used for the `issuer` of a credential and the `holder` of a presentation.
+// It is immutable: create it using NewIdentifier or NewIdentifierObject.
+type IdentifierOrObject struct {
+	id ssi.URI
+	// properties contains the properties of the object other than `id`. It is nil if the value is a plain URI.
+	properties map[string]interface{}
+}
+
+// NewIdentifier creates an IdentifierOrObject that is marshalled as plain URI.
+func NewIdentifier(id ssi.URI) IdentifierOrObject {
+	return IdentifierOrObject{id: id}
+}
+
+// NewIdentifierObject creates an IdentifierOrObject that is marshalled as object with the given ID and additional properties.
+// An `id` in properties is ignored.
+func NewIdentifierObject(id ssi.URI, properties map[string]interface{}) IdentifierOrObject {
+	result := IdentifierOrObject{id: id, properties: clone.Of(properties)}
+	if result.properties == nil {
+		result.properties = map[string]interface{}{}
+	}
+	delete(result.properties, "id")
+	return result
+}
+
+// ID returns the identifier.
+func (i IdentifierOrObject) ID() ssi.URI {
+	return i.id
+}
+
+// DID returns the identifier as DID. It returns an error if the identifier is not a valid DID.
+func (i IdentifierOrObject) DID() (*did.DID, error) {
+	return did.ParseDID(i.id.String())
+}
+
+// IsObject returns true if the value is an object, rather than a plain URI.
+func (i IdentifierOrObject) IsObject() bool {
+	return i.properties != nil
+}
+
+// Properties returns a copy of the properties of the object other than `id`, or nil if the value is a plain URI.
+func (i IdentifierOrObject) Properties() map[string]interface{} {
+	return clone.Of(i.properties)
+}
+
+// String returns the identifier as string.
+func (i IdentifierOrObject) String() string {
+	return i.id.String()
+}
+
+func (i IdentifierOrObject) MarshalJSON() ([]byte, error) {
+	if i.properties == nil {
+		return json.Marshal(i.id)
+	}
+	result := make(map[string]interface{}, len(i.properties)+1)
+	for key, value := range i.properties {
+		result[key] = value
+	}
+	if i.id.String() != "" {
+		result["id"] = i.id
+	}
+	return json.Marshal(result)
+}
+
+func (i *IdentifierOrObject) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	switch value := raw.(type) {
+	case string:
+		id, err := ssi.ParseURI(value)
+		if err != nil {
+			return err
+		}
+		*i = NewIdentifier(*id)
+	case map[string]interface{}:
+		var id ssi.URI
+		if rawID, ok := value["id"]; ok {
+			idStr, ok := rawID.(string)
+			if !ok {
+				return errors.New("invalid identifier object: id must be a string")
+			}
+			parsed, err := ssi.ParseURI(idStr)
+			if err != nil {
+				return fmt.Errorf("invalid identifier object: %w", err)
+			}
+			id = *parsed
+		}
+		delete(value, "id")
+		*i = IdentifierOrObject{id: id, properties: value}
+	default:
+		return errors.New("invalid identifier: expected string or object")
+	}
+	return nil
+}
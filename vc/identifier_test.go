@@ -0,0 +1,94 @@
+package vc
+
+import (
+	"encoding/json"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifierOrObject_UnmarshalJSON(t *testing.T) {
+	t.Run("URI", func(t *testing.T) {
+		var actual IdentifierOrObject
+		require.NoError(t, json.Unmarshal([]byte(`"did:example:issuer"`), &actual))
+
+		assert.Equal(t, "did:example:issuer", actual.ID().String())
+		assert.False(t, actual.IsObject())
+		assert.Nil(t, actual.Properties())
+	})
+	t.Run("object", func(t *testing.T) {
+		var actual IdentifierOrObject
+		require.NoError(t, json.Unmarshal([]byte(`{"id": "did:example:issuer", "name": "ACME"}`), &actual))
+
+		assert.Equal(t, "did:example:issuer", actual.ID().String())
+		assert.True(t, actual.IsObject())
+		assert.Equal(t, map[string]interface{}{"name": "ACME"}, actual.Properties())
+	})
+	t.Run("object without id", func(t *testing.T) {
+		var actual IdentifierOrObject
+		require.NoError(t, json.Unmarshal([]byte(`{"name": "ACME"}`), &actual))
+
+		assert.Empty(t, actual.String())
+		assert.True(t, actual.IsObject())
+	})
+	t.Run("id is not a string", func(t *testing.T) {
+		var actual IdentifierOrObject
+		err := json.Unmarshal([]byte(`{"id": 1}`), &actual)
+
+		assert.EqualError(t, err, "invalid identifier object: id must be a string")
+	})
+	t.Run("invalid type", func(t *testing.T) {
+		var actual IdentifierOrObject
+		err := json.Unmarshal([]byte(`true`), &actual)
+
+		assert.EqualError(t, err, "invalid identifier: expected string or object")
+	})
+}
+
+func TestIdentifierOrObject_MarshalJSON(t *testing.T) {
+	t.Run("URI", func(t *testing.T) {
+		data, err := json.Marshal(NewIdentifier(ssi.MustParseURI("did:example:issuer")))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `"did:example:issuer"`, string(data))
+	})
+	t.Run("object", func(t *testing.T) {
+		data, err := json.Marshal(NewIdentifierObject(ssi.MustParseURI("did:example:issuer"), map[string]interface{}{"name": "ACME", "id": "ignored"}))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "did:example:issuer", "name": "ACME"}`, string(data))
+	})
+	t.Run("object without properties", func(t *testing.T) {
+		data, err := json.Marshal(NewIdentifierObject(ssi.MustParseURI("did:example:issuer"), nil))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "did:example:issuer"}`, string(data))
+	})
+}
+
+func TestIdentifierOrObject_DID(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		actual, err := NewIdentifierObject(ssi.MustParseURI("did:example:issuer"), map[string]interface{}{"name": "ACME"}).DID()
+
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:issuer", actual.String())
+	})
+	t.Run("not a DID", func(t *testing.T) {
+		_, err := NewIdentifier(ssi.MustParseURI("https://example.com/issuer")).DID()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIdentifierOrObject_Properties(t *testing.T) {
+	properties := map[string]interface{}{"name": "ACME"}
+	identifier := NewIdentifierObject(ssi.MustParseURI("did:example:issuer"), properties)
+
+	// changes to the input or output must not alter the identifier
+	properties["name"] = "changed"
+	identifier.Properties()["name"] = "changed"
+
+	assert.Equal(t, map[string]interface{}{"name": "ACME"}, identifier.Properties())
+}
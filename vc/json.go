@@ -9,6 +9,16 @@ const (
 	typeKey                 = "type"
 	credentialSubjectKey    = "credentialSubject"
 	credentialStatusKey     = "credentialStatus"
+	credentialSchemaKey     = "credentialSchema"
+	relatedResourceKey      = "relatedResource"
+	refreshServiceKey       = "refreshService"
+	termsOfUseKey           = "termsOfUse"
+	evidenceKey             = "evidence"
+	issuanceDateKey         = "issuanceDate"
+	validFromKey            = "validFrom"
+	validUntilKey           = "validUntil"
+	nameKey                 = "name"
+	descriptionKey          = "description"
 	proofKey                = "proof"
 	verifiableCredentialKey = "verifiableCredential"
 )
@@ -0,0 +1,111 @@
+package vc
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// LanguageValue is a string value with an optional language tag (e.g. "en") and base direction (e.g. "ltr"),
+// as used by the VCDM 2.0 name and description properties (https://www.w3.org/TR/vc-data-model-2.0/#language-and-base-direction).
+type LanguageValue struct {
+	Value     string `json:"@value"`
+	Language  string `json:"@language,omitempty"`
+	Direction string `json:"@direction,omitempty"`
+}
+
+// LanguageValues holds one or more values of a property in different languages.
+// It can be unmarshalled from a plain string, a value object (e.g. {"@value": "Name", "@language": "en"}),
+// an array of strings and value objects, or a JSON-LD language map (e.g. {"en": "Name", "nl": "Naam"}).
+// It is marshalled as plain string if it contains a single value without language and direction,
+// and as (array of) value objects otherwise, which means language maps are converted to value objects.
+type LanguageValues []LanguageValue
+
+// Get returns the value in the given language. If there's no such value, it returns the value without language,
+// or the first value if all values have a language. It returns an empty string if there are no values.
+func (l LanguageValues) Get(language string) string {
+	if len(l) == 0 {
+		return ""
+	}
+	fallback := l[0].Value
+	for _, value := range l {
+		if value.Language == language {
+			return value.Value
+		}
+		if value.Language == "" {
+			fallback = value.Value
+		}
+	}
+	return fallback
+}
+
+func (l LanguageValues) MarshalJSON() ([]byte, error) {
+	switch {
+	case len(l) == 1 && l[0].Language == "" && l[0].Direction == "":
+		return json.Marshal(l[0].Value)
+	case len(l) == 1:
+		return json.Marshal(l[0])
+	default:
+		return json.Marshal([]LanguageValue(l))
+	}
+}
+
+func (l *LanguageValues) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var result LanguageValues
+	switch value := raw.(type) {
+	case []interface{}:
+		for _, entry := range value {
+			parsed, err := parseLanguageValue(entry)
+			if err != nil {
+				return err
+			}
+			result = append(result, parsed...)
+		}
+	default:
+		parsed, err := parseLanguageValue(value)
+		if err != nil {
+			return err
+		}
+		result = parsed
+	}
+	*l = result
+	return nil
+}
+
+// parseLanguageValue parses a string, value object or language map into language values.
+func parseLanguageValue(input interface{}) ([]LanguageValue, error) {
+	switch value := input.(type) {
+	case string:
+		return []LanguageValue{{Value: value}}, nil
+	case map[string]interface{}:
+		if _, isValueObject := value["@value"]; isValueObject {
+			asJSON, _ := json.Marshal(value)
+			var result LanguageValue
+			if err := json.Unmarshal(asJSON, &result); err != nil {
+				return nil, errors.New("invalid value object: " + err.Error())
+			}
+			return []LanguageValue{result}, nil
+		}
+		// language map, ordered by language for deterministic results
+		languages := make([]string, 0, len(value))
+		for language := range value {
+			languages = append(languages, language)
+		}
+		sort.Strings(languages)
+		var result []LanguageValue
+		for _, language := range languages {
+			text, ok := value[language].(string)
+			if !ok {
+				return nil, errors.New("invalid language map: value for '" + language + "' is not a string")
+			}
+			result = append(result, LanguageValue{Value: text, Language: language})
+		}
+		return result, nil
+	default:
+		return nil, errors.New("invalid language value: expected string, value object or language map")
+	}
+}
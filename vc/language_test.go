@@ -0,0 +1,81 @@
+package vc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguageValues_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected LanguageValues
+	}{
+		{
+			name:     "string",
+			input:    `"Example"`,
+			expected: LanguageValues{{Value: "Example"}},
+		},
+		{
+			name:     "value object",
+			input:    `{"@value": "Example", "@language": "en", "@direction": "ltr"}`,
+			expected: LanguageValues{{Value: "Example", Language: "en", Direction: "ltr"}},
+		},
+		{
+			name:     "array",
+			input:    `["Example", {"@value": "Voorbeeld", "@language": "nl"}]`,
+			expected: LanguageValues{{Value: "Example"}, {Value: "Voorbeeld", Language: "nl"}},
+		},
+		{
+			name:     "language map",
+			input:    `{"nl": "Voorbeeld", "en": "Example"}`,
+			expected: LanguageValues{{Value: "Example", Language: "en"}, {Value: "Voorbeeld", Language: "nl"}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var actual LanguageValues
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &actual))
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+	t.Run("invalid type", func(t *testing.T) {
+		var actual LanguageValues
+		err := json.Unmarshal([]byte(`123`), &actual)
+		assert.EqualError(t, err, "invalid language value: expected string, value object or language map")
+	})
+	t.Run("invalid language map", func(t *testing.T) {
+		var actual LanguageValues
+		err := json.Unmarshal([]byte(`{"en": 123}`), &actual)
+		assert.EqualError(t, err, "invalid language map: value for 'en' is not a string")
+	})
+}
+
+func TestLanguageValues_MarshalJSON(t *testing.T) {
+	t.Run("single plain value", func(t *testing.T) {
+		data, err := json.Marshal(LanguageValues{{Value: "Example"}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `"Example"`, string(data))
+	})
+	t.Run("single value with language", func(t *testing.T) {
+		data, err := json.Marshal(LanguageValues{{Value: "Example", Language: "en"}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"@value": "Example", "@language": "en"}`, string(data))
+	})
+	t.Run("multiple values", func(t *testing.T) {
+		data, err := json.Marshal(LanguageValues{{Value: "Example", Language: "en"}, {Value: "Voorbeeld", Language: "nl"}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"@value": "Example", "@language": "en"}, {"@value": "Voorbeeld", "@language": "nl"}]`, string(data))
+	})
+}
+
+func TestLanguageValues_Get(t *testing.T) {
+	values := LanguageValues{{Value: "Voorbeeld", Language: "nl"}, {Value: "Example"}}
+	assert.Equal(t, "Voorbeeld", values.Get("nl"))
+	assert.Equal(t, "Example", values.Get("de"))
+	assert.Equal(t, "Voorbeeld", LanguageValues{{Value: "Voorbeeld", Language: "nl"}}.Get("en"))
+	assert.Equal(t, "", LanguageValues{}.Get("en"))
+}
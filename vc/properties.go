@@ -0,0 +1,129 @@
+package vc
+
+import (
+	"bytes"
+	"encoding/json"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/internal/marshal"
+)
+
+// RefreshService contains the Type(s) and optional ID of a refreshService (https://www.w3.org/TR/vc-data-model-2.0/#refreshing),
+// and the raw data for unmarshalling into a custom type. Properties other than ID and Type are retained when marshalling.
+type RefreshService struct {
+	ID   *ssi.URI `json:"id,omitempty"`
+	Type []string `json:"type"`
+	raw  []byte
+}
+
+func (r *RefreshService) UnmarshalJSON(input []byte) error {
+	type alias RefreshService
+	var err error
+	r.raw, err = unmarshalTyped(input, (*alias)(r))
+	return err
+}
+
+func (r RefreshService) MarshalJSON() ([]byte, error) {
+	return marshalTyped(r.raw, optionalURI(r.ID), r.Type)
+}
+
+// Raw returns a copy of the underlying refreshService data as set during UnmarshalJSON.
+func (r RefreshService) Raw() []byte {
+	return bytes.Clone(r.raw)
+}
+
+// TermsOfUse contains the Type(s) and optional ID of a termsOfUse entry (https://www.w3.org/TR/vc-data-model-2.0/#terms-of-use),
+// and the raw data for unmarshalling into a custom type. Properties other than ID and Type are retained when marshalling.
+type TermsOfUse struct {
+	ID   *ssi.URI `json:"id,omitempty"`
+	Type []string `json:"type"`
+	raw  []byte
+}
+
+func (t *TermsOfUse) UnmarshalJSON(input []byte) error {
+	type alias TermsOfUse
+	var err error
+	t.raw, err = unmarshalTyped(input, (*alias)(t))
+	return err
+}
+
+func (t TermsOfUse) MarshalJSON() ([]byte, error) {
+	return marshalTyped(t.raw, optionalURI(t.ID), t.Type)
+}
+
+// Raw returns a copy of the underlying termsOfUse data as set during UnmarshalJSON.
+func (t TermsOfUse) Raw() []byte {
+	return bytes.Clone(t.raw)
+}
+
+// Evidence contains the Type(s) and optional ID of an evidence entry (https://www.w3.org/TR/vc-data-model-2.0/#evidence),
+// and the raw data for unmarshalling into a custom type. Properties other than ID and Type are retained when marshalling.
+type Evidence struct {
+	ID   *ssi.URI `json:"id,omitempty"`
+	Type []string `json:"type"`
+	raw  []byte
+}
+
+func (e *Evidence) UnmarshalJSON(input []byte) error {
+	type alias Evidence
+	var err error
+	e.raw, err = unmarshalTyped(input, (*alias)(e))
+	return err
+}
+
+func (e Evidence) MarshalJSON() ([]byte, error) {
+	return marshalTyped(e.raw, optionalURI(e.ID), e.Type)
+}
+
+// Raw returns a copy of the underlying evidence data as set during UnmarshalJSON.
+func (e Evidence) Raw() []byte {
+	return bytes.Clone(e.raw)
+}
+
+func optionalURI(uri *ssi.URI) string {
+	if uri == nil {
+		return ""
+	}
+	return uri.String()
+}
+
+// unmarshalTyped unmarshals a JSON object with an `id` and `type` (which may be a single value or an array) into target,
+// and returns a compacted copy of the input.
+func unmarshalTyped(input []byte, target interface{}) ([]byte, error) {
+	normalized, err := marshal.NormalizeDocument(input, marshal.Plural(typeKey))
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(normalized, target); err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err = json.Compact(buf, input); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalTyped marshals the raw JSON object with the given `id` and `type` set. A single type is marshalled as string.
+// An empty ID or type is omitted.
+func marshalTyped(raw []byte, id string, types []string) ([]byte, error) {
+	result := map[string]interface{}{}
+	if raw != nil {
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, err
+		}
+	}
+	delete(result, "id")
+	delete(result, typeKey)
+	if id != "" {
+		result["id"] = id
+	}
+	switch len(types) {
+	case 0:
+	case 1:
+		result[typeKey] = types[0]
+	default:
+		result[typeKey] = types
+	}
+	return json.Marshal(result)
+}
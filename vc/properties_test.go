@@ -0,0 +1,91 @@
+package vc
+
+import (
+	"encoding/json"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshService_MarshalJSON(t *testing.T) {
+	t.Run("retains unknown properties", func(t *testing.T) {
+		var refreshService RefreshService
+		require.NoError(t, json.Unmarshal([]byte(`{"id": "https://example.com/refresh", "type": "Custom", "url": "https://example.com"}`), &refreshService))
+
+		data, err := json.Marshal(refreshService)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "https://example.com/refresh", "type": "Custom", "url": "https://example.com"}`, string(data))
+	})
+	t.Run("fields override raw data", func(t *testing.T) {
+		var refreshService RefreshService
+		require.NoError(t, json.Unmarshal([]byte(`{"id": "https://example.com/refresh", "type": "Custom"}`), &refreshService))
+		refreshService.ID = nil
+		refreshService.Type = []string{"Other"}
+
+		data, err := json.Marshal(refreshService)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type": "Other"}`, string(data))
+	})
+	t.Run("empty", func(t *testing.T) {
+		data, err := json.Marshal(RefreshService{})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(data))
+	})
+}
+
+func TestTermsOfUse_UnmarshalJSON(t *testing.T) {
+	t.Run("type array", func(t *testing.T) {
+		var termsOfUse TermsOfUse
+		require.NoError(t, json.Unmarshal([]byte(`{"type": ["A", "B"]}`), &termsOfUse))
+
+		assert.Equal(t, []string{"A", "B"}, termsOfUse.Type)
+	})
+	t.Run("not an object", func(t *testing.T) {
+		var termsOfUse TermsOfUse
+		assert.Error(t, json.Unmarshal([]byte(`"https://example.com/terms"`), &termsOfUse))
+	})
+}
+
+func TestTermsOfUse_MarshalJSON(t *testing.T) {
+	id := ssi.MustParseURI("https://example.com/policy")
+	data, err := json.Marshal(TermsOfUse{ID: &id, Type: []string{"IssuerPolicy"}})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "https://example.com/policy", "type": "IssuerPolicy"}`, string(data))
+}
+
+func TestEvidence_UnmarshalJSON(t *testing.T) {
+	t.Run("single type", func(t *testing.T) {
+		var evidence Evidence
+		require.NoError(t, json.Unmarshal([]byte(`{"type": "DocumentVerification", "verifier": "did:example:verifier"}`), &evidence))
+
+		assert.Equal(t, []string{"DocumentVerification"}, evidence.Type)
+		assert.Nil(t, evidence.ID)
+		assert.Equal(t, `{"type":"DocumentVerification","verifier":"did:example:verifier"}`, string(evidence.Raw()))
+	})
+	t.Run("multiple types", func(t *testing.T) {
+		var evidence Evidence
+		require.NoError(t, json.Unmarshal([]byte(`{"type": ["DocumentVerification", "Other"]}`), &evidence))
+
+		assert.Equal(t, []string{"DocumentVerification", "Other"}, evidence.Type)
+		data, err := json.Marshal(evidence)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type": ["DocumentVerification", "Other"]}`, string(data))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var evidence Evidence
+		assert.Error(t, json.Unmarshal([]byte(`{"type": 1}`), &evidence))
+	})
+}
+
+func TestEvidence_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Evidence{})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(data))
+}
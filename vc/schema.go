@@ -0,0 +1,228 @@
+package vc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	ssi "github.com/nuts-foundation/go-did"
+)
+
+// JsonSchemaType is the credentialSchema type for schemas that are JSON Schema documents (https://www.w3.org/TR/vc-json-schema/#jsonschema).
+const JsonSchemaType = "JsonSchema"
+
+// JsonSchemaCredentialType is the credentialSchema type for schemas that are wrapped in a credential (https://www.w3.org/TR/vc-json-schema/#jsonschemacredential).
+// The JSON Schema is contained in the credentialSubject's jsonSchema property.
+const JsonSchemaCredentialType = "JsonSchemaCredential"
+
+// ErrInvalidCredentialSchema indicates the `credentialSchema` of the credential is invalid, or the referenced schema can't be loaded or compiled
+var ErrInvalidCredentialSchema = errors.New("invalid credentialSchema")
+
+// ErrSchemaViolation indicates the credential does not conform to its credentialSchema
+var ErrSchemaViolation = errors.New("credential does not conform to credentialSchema")
+
+// CredentialSchema contains the required fields ID and Type, and the raw data for unmarshalling into a custom type.
+// Properties other than ID and Type are retained when marshalling.
+// Type holds multiple values, since it may be a single type or an array of types.
+type CredentialSchema struct {
+	ID   ssi.URI  `json:"id"`
+	Type []string `json:"type"`
+	raw  []byte
+}
+
+func (cs *CredentialSchema) UnmarshalJSON(input []byte) error {
+	type alias CredentialSchema
+	var err error
+	cs.raw, err = unmarshalTyped(input, (*alias)(cs))
+	return err
+}
+
+func (cs CredentialSchema) MarshalJSON() ([]byte, error) {
+	return marshalTyped(cs.raw, cs.ID.String(), cs.Type)
+}
+
+// Raw returns a copy of the underlying credentialSchema data as set during UnmarshalJSON.
+// This can be used to marshal the data into a custom type (e.g. to read digestSRI).
+func (cs CredentialSchema) Raw() []byte {
+	return bytes.Clone(cs.raw)
+}
+
+// schemaType returns the supported type of the credentialSchema (JsonSchema or JsonSchemaCredential).
+func (cs CredentialSchema) schemaType() (string, error) {
+	for _, curr := range cs.Type {
+		if curr == JsonSchemaType || curr == JsonSchemaCredentialType {
+			return curr, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported credentialSchema type: %s", strings.Join(cs.Type, ", "))
+}
+
+// SchemaLoader loads the document at the given URL, which is either a JSON Schema or a credential containing one (for JsonSchemaCredential).
+// Loaders that don't fetch documents from the network allow schema validation to work offline.
+type SchemaLoader interface {
+	Load(url string) ([]byte, error)
+}
+
+// SchemaLoaderFunc adapts a function to a SchemaLoader.
+type SchemaLoaderFunc func(url string) ([]byte, error)
+
+func (f SchemaLoaderFunc) Load(url string) ([]byte, error) {
+	return f(url)
+}
+
+// MapSchemaLoader is a SchemaLoader that loads documents from memory, keyed by URL.
+type MapSchemaLoader map[string][]byte
+
+func (m MapSchemaLoader) Load(url string) ([]byte, error) {
+	document, ok := m[url]
+	if !ok {
+		return nil, fmt.Errorf("schema not found: %s", url)
+	}
+	return document, nil
+}
+
+// JSONSchemaValidator validates a credential against the JSON Schemas referenced by its credentialSchema (https://www.w3.org/TR/vc-json-schema/).
+// The schema applies to the credential as a whole; credentials in JWT format are validated in their JSON-LD representation.
+// Schemas are loaded using the given SchemaLoader (also for schemas referenced through `$ref`), and cached after compilation.
+// Credentials without credentialSchema are valid.
+type JSONSchemaValidator struct {
+	loader  SchemaLoader
+	mux     sync.Mutex
+	schemas map[string]*jsonschema.Schema
+}
+
+// NewJSONSchemaValidator creates a JSONSchemaValidator that loads schemas using the given loader.
+func NewJSONSchemaValidator(loader SchemaLoader) *JSONSchemaValidator {
+	return &JSONSchemaValidator{
+		loader:  loader,
+		schemas: map[string]*jsonschema.Schema{},
+	}
+}
+
+func (j *JSONSchemaValidator) Validate(credential VerifiableCredential) error {
+	return firstError(ErrCredentialInvalid, j.Report(credential))
+}
+
+// Report validates the credential against each of its schemas and returns all findings.
+// Schema violations are reported with the JSON pointer of the offending value in the credential.
+func (j *JSONSchemaValidator) Report(credential VerifiableCredential) ssi.ValidationReport {
+	result := ssi.ValidationReport{}
+	if len(credential.CredentialSchema) == 0 {
+		return result
+	}
+	instance, err := credentialAsJSONValue(credential)
+	if err != nil {
+		result.AddError("", ErrInvalidCredentialSchema, "unable to marshal credential: "+err.Error())
+		return result
+	}
+	for i, credentialSchema := range credential.CredentialSchema {
+		pointer := ssi.JSONPointer(credentialSchemaKey, i)
+		schema, err := j.compile(credentialSchema)
+		if err != nil {
+			result.AddError(pointer, ErrInvalidCredentialSchema, err.Error())
+			continue
+		}
+		err = schema.Validate(instance)
+		var validationErr *jsonschema.ValidationError
+		if errors.As(err, &validationErr) {
+			for _, unit := range leafErrors(*validationErr.DetailedOutput()) {
+				result.AddError(unit.InstanceLocation, ErrSchemaViolation, fmt.Sprintf("%s (schema %s)", unit.Error.String(), credentialSchema.ID.String()))
+			}
+		} else if err != nil {
+			result.AddError(pointer, ErrInvalidCredentialSchema, err.Error())
+		}
+	}
+	return result
+}
+
+// compile loads and compiles the JSON Schema referenced by the given credentialSchema, or returns it from the cache.
+func (j *JSONSchemaValidator) compile(credentialSchema CredentialSchema) (*jsonschema.Schema, error) {
+	schemaType, err := credentialSchema.schemaType()
+	if err != nil {
+		return nil, err
+	}
+	key := schemaType + " " + credentialSchema.ID.String()
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	if schema, ok := j.schemas[key]; ok {
+		return schema, nil
+	}
+	url := credentialSchema.ID.String()
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(schemaLoaderAdapter{loader: j.loader})
+	switch schemaType {
+	case JsonSchemaType:
+		// loaded by the compiler
+	case JsonSchemaCredentialType:
+		document, err := j.loadSchemaCredential(url)
+		if err != nil {
+			return nil, err
+		}
+		if err = compiler.AddResource(url, document); err != nil {
+			return nil, fmt.Errorf("unable to add schema %s: %w", url, err)
+		}
+	}
+	schema, err := compiler.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile schema %s: %w", url, err)
+	}
+	j.schemas[key] = schema
+	return schema, nil
+}
+
+// loadSchemaCredential loads the JsonSchemaCredential at the given URL, and returns the JSON Schema from its credentialSubject.
+func (j *JSONSchemaValidator) loadSchemaCredential(url string) (interface{}, error) {
+	data, err := j.loader.Load(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load schema %s: %w", url, err)
+	}
+	schemaCredential, err := ParseVerifiableCredential(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse schema credential %s: %w", url, err)
+	}
+	if len(schemaCredential.CredentialSubject) != 1 || schemaCredential.CredentialSubject[0]["jsonSchema"] == nil {
+		return nil, fmt.Errorf("schema credential %s does not contain a jsonSchema", url)
+	}
+	asJSON, _ := json.Marshal(schemaCredential.CredentialSubject[0]["jsonSchema"])
+	return jsonschema.UnmarshalJSON(bytes.NewReader(asJSON))
+}
+
+// schemaLoaderAdapter adapts a SchemaLoader to the jsonschema.URLLoader interface.
+type schemaLoaderAdapter struct {
+	loader SchemaLoader
+}
+
+func (s schemaLoaderAdapter) Load(url string) (any, error) {
+	data, err := s.loader.Load(url)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
+
+// credentialAsJSONValue returns the JSON-LD representation of the credential as generic JSON value, as expected by the JSON Schema validator.
+func credentialAsJSONValue(credential VerifiableCredential) (interface{}, error) {
+	credential.format = JSONLDCredentialProofFormat
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
+
+// leafErrors returns the output units that describe an actual violation, omitting units that only aggregate the errors of their children.
+func leafErrors(output jsonschema.OutputUnit) []jsonschema.OutputUnit {
+	var result []jsonschema.OutputUnit
+	if len(output.Errors) == 0 && output.Error != nil {
+		result = append(result, output)
+	}
+	for _, child := range output.Errors {
+		result = append(result, leafErrors(child)...)
+	}
+	return result
+}
@@ -0,0 +1,220 @@
+package vc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"properties": {
+		"credentialSubject": {
+			"type": "object",
+			"properties": {
+				"name": {"type": "string"},
+				"address": {"$ref": "https://example.com/schemas/address.json"}
+			},
+			"required": ["name"]
+		}
+	}
+}`
+
+const testAddressSchema = `{
+	"type": "object",
+	"properties": {"city": {"type": "string"}},
+	"required": ["city"]
+}`
+
+const testSchemaCredential = `{
+	"@context": ["https://www.w3.org/ns/credentials/v2"],
+	"id": "https://example.com/credentials/schema",
+	"type": ["VerifiableCredential", "JsonSchemaCredential"],
+	"issuer": "did:example:issuer",
+	"credentialSubject": {
+		"id": "https://example.com/schemas/person.json",
+		"type": "JsonSchema",
+		"jsonSchema": {
+			"type": "object",
+			"properties": {"credentialSubject": {"type": "object", "required": ["age"]}}
+		}
+	}
+}`
+
+func TestCredentialSchema_JSONMarshalling(t *testing.T) {
+	t.Run("parse single and multiple schemas", func(t *testing.T) {
+		credential, err := ParseVerifiableCredential(`{
+			"@context": ["https://www.w3.org/2018/credentials/v1"],
+			"type": "VerifiableCredential",
+			"credentialSchema": {"id": "https://example.com/schemas/person.json", "type": "JsonSchema", "digestSRI": "sha384-abc"},
+			"credentialSubject": {"name": "Alice"}
+		}`)
+		require.NoError(t, err)
+
+		require.Len(t, credential.CredentialSchema, 1)
+		assert.Equal(t, "https://example.com/schemas/person.json", credential.CredentialSchema[0].ID.String())
+		assert.Equal(t, []string{JsonSchemaType}, credential.CredentialSchema[0].Type)
+		assert.JSONEq(t, `{"id": "https://example.com/schemas/person.json", "type": "JsonSchema", "digestSRI": "sha384-abc"}`, string(credential.CredentialSchema[0].Raw()))
+	})
+	t.Run("type array", func(t *testing.T) {
+		credential, err := ParseVerifiableCredential(`{
+			"@context": ["https://www.w3.org/2018/credentials/v1"],
+			"type": "VerifiableCredential",
+			"credentialSchema": {"id": "https://example.com/schemas/person.json", "type": ["JsonSchema", "Other"]},
+			"credentialSubject": {"name": "Alice"}
+		}`)
+		require.NoError(t, err)
+
+		require.Len(t, credential.CredentialSchema, 1)
+		assert.Equal(t, []string{JsonSchemaType, "Other"}, credential.CredentialSchema[0].Type)
+		data, err := json.Marshal(credential.CredentialSchema[0])
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "https://example.com/schemas/person.json", "type": ["JsonSchema", "Other"]}`, string(data))
+	})
+	t.Run("marshal retains other properties", func(t *testing.T) {
+		var schema CredentialSchema
+		require.NoError(t, json.Unmarshal([]byte(`{"id": "https://example.com/1", "type": "JsonSchema", "digestSRI": "sha384-abc"}`), &schema))
+		schema.ID = ssi.MustParseURI("https://example.com/2")
+
+		data, err := json.Marshal(schema)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "https://example.com/2", "type": "JsonSchema", "digestSRI": "sha384-abc"}`, string(data))
+	})
+	t.Run("single schema is marshalled as object", func(t *testing.T) {
+		credential := credentialV1()
+		credential.CredentialSchema = []CredentialSchema{{ID: ssi.MustParseURI("https://example.com/1"), Type: []string{JsonSchemaType}}}
+
+		data, err := json.Marshal(credential)
+
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"credentialSchema":{"id":"https://example.com/1","type":"JsonSchema"}`)
+	})
+	t.Run("raw is nil when not unmarshalled", func(t *testing.T) {
+		assert.Nil(t, CredentialSchema{}.Raw())
+	})
+}
+
+func TestJSONSchemaValidator(t *testing.T) {
+	loader := MapSchemaLoader{
+		"https://example.com/schemas/person.json":  []byte(testSchema),
+		"https://example.com/schemas/address.json": []byte(testAddressSchema),
+		"https://example.com/credentials/schema":   []byte(testSchemaCredential),
+	}
+	credential := func(schemaType string, schemaID string, subject map[string]any) VerifiableCredential {
+		result := credentialV2()
+		result.CredentialSchema = []CredentialSchema{{ID: ssi.MustParseURI(schemaID), Type: []string{schemaType}}}
+		result.CredentialSubject = []map[string]any{subject}
+		return result
+	}
+	findings := func(report ssi.ValidationReport) []string {
+		var result []string
+		for _, finding := range report.Findings {
+			result = append(result, finding.String())
+		}
+		return result
+	}
+	t.Run("ok", func(t *testing.T) {
+		input := credential(JsonSchemaType, "https://example.com/schemas/person.json", map[string]any{"name": "Alice", "address": map[string]any{"city": "Amsterdam"}})
+
+		assert.NoError(t, NewJSONSchemaValidator(loader).Validate(input))
+	})
+	t.Run("ok - no credentialSchema", func(t *testing.T) {
+		assert.NoError(t, NewJSONSchemaValidator(loader).Validate(credentialV1()))
+	})
+	t.Run("ok - JWT credential", func(t *testing.T) {
+		parsed, err := ParseVerifiableCredential(jwtCredential)
+		require.NoError(t, err)
+		parsed.CredentialSchema = []CredentialSchema{{ID: ssi.MustParseURI("https://example.com/schemas/any.json"), Type: []string{JsonSchemaType}}}
+
+		err = NewJSONSchemaValidator(MapSchemaLoader{"https://example.com/schemas/any.json": []byte(`{"required": ["credentialSubject"]}`)}).Validate(*parsed)
+
+		assert.NoError(t, err)
+	})
+	t.Run("schema violations", func(t *testing.T) {
+		input := credential(JsonSchemaType, "https://example.com/schemas/person.json", map[string]any{"name": 1, "address": map[string]any{}})
+
+		report := NewJSONSchemaValidator(loader).Report(input)
+
+		assert.ElementsMatch(t, []string{
+			"error at /credentialSubject/name: got number, want string (schema https://example.com/schemas/person.json)",
+			"error at /credentialSubject/address: missing property 'city' (schema https://example.com/schemas/person.json)",
+		}, findings(report))
+		err := NewJSONSchemaValidator(loader).Validate(input)
+		assert.ErrorIs(t, err, ErrCredentialInvalid)
+		assert.ErrorIs(t, err, ErrSchemaViolation)
+	})
+	t.Run("JsonSchemaCredential", func(t *testing.T) {
+		validator := NewJSONSchemaValidator(loader)
+		t.Run("ok", func(t *testing.T) {
+			input := credential(JsonSchemaCredentialType, "https://example.com/credentials/schema", map[string]any{"age": 30})
+
+			assert.NoError(t, validator.Validate(input))
+		})
+		t.Run("violation", func(t *testing.T) {
+			input := credential(JsonSchemaCredentialType, "https://example.com/credentials/schema", map[string]any{"name": "Alice"})
+
+			report := validator.Report(input)
+
+			assert.Equal(t, []string{"error at /credentialSubject: missing property 'age' (schema https://example.com/credentials/schema)"}, findings(report))
+		})
+		t.Run("credential without jsonSchema", func(t *testing.T) {
+			input := credential(JsonSchemaCredentialType, "https://example.com/schemas/person.json", map[string]any{"name": "Alice"})
+
+			report := validator.Report(input)
+
+			require.Len(t, report.Findings, 1)
+			assert.Equal(t, "/credentialSchema/0", report.Findings[0].Pointer)
+			assert.ErrorIs(t, report.Findings[0].Code, ErrInvalidCredentialSchema)
+		})
+	})
+	t.Run("schema can't be loaded", func(t *testing.T) {
+		input := credential(JsonSchemaType, "https://example.com/schemas/unknown.json", map[string]any{"name": "Alice"})
+
+		report := NewJSONSchemaValidator(loader).Report(input)
+
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, "/credentialSchema/0", report.Findings[0].Pointer)
+		assert.Contains(t, report.Findings[0].Message, "unable to compile schema https://example.com/schemas/unknown.json")
+		assert.ErrorIs(t, NewJSONSchemaValidator(loader).Validate(input), ErrInvalidCredentialSchema)
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		input := credential("JsonSchemaValidator2018", "https://example.com/schemas/person.json", map[string]any{"name": "Alice"})
+
+		report := NewJSONSchemaValidator(loader).Report(input)
+
+		assert.Equal(t, []string{"error at /credentialSchema/0: unsupported credentialSchema type: JsonSchemaValidator2018"}, findings(report))
+	})
+	t.Run("type array with supported type", func(t *testing.T) {
+		input := credential(JsonSchemaType, "https://example.com/schemas/person.json", map[string]any{"name": "Alice"})
+		input.CredentialSchema[0].Type = []string{"Other", JsonSchemaType}
+
+		assert.NoError(t, NewJSONSchemaValidator(loader).Validate(input))
+	})
+	t.Run("compiled schemas are cached", func(t *testing.T) {
+		calls := 0
+		countingLoader := SchemaLoaderFunc(func(url string) ([]byte, error) {
+			calls++
+			return loader.Load(url)
+		})
+		validator := NewJSONSchemaValidator(countingLoader)
+		input := credential(JsonSchemaType, "https://example.com/schemas/person.json", map[string]any{"name": "Alice"})
+
+		require.NoError(t, validator.Validate(input))
+		require.NoError(t, validator.Validate(input))
+
+		assert.Equal(t, 2, calls) // person.json and address.json
+	})
+}
+
+func TestMapSchemaLoader_Load(t *testing.T) {
+	_, err := MapSchemaLoader{}.Load("https://example.com")
+
+	assert.EqualError(t, err, "schema not found: https://example.com")
+	assert.False(t, errors.Is(err, ErrInvalidCredentialSchema))
+}
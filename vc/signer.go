@@ -0,0 +1,91 @@
+package vc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jws"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+	"github.com/nuts-foundation/go-did/did"
+)
+
+// NewJWTSigner creates a JWTSigner that signs with the given crypto.Signer, on behalf of the given verification method.
+// It sets the `kid` header to the ID of the verification method and selects the `alg` from the key:
+// EdDSA for Ed25519, ES256, ES384 or ES512 for P-256, P-384 or P-521, ES256K for secp256k1 and PS256 for RSA.
+// Signing with ES256K requires the jwx_es256k build tag.
+// It returns an error if the public key of the signer doesn't match the verification method.
+func NewJWTSigner(signer crypto.Signer, method did.VerificationMethod) (JWTSigner, error) {
+	if signer == nil {
+		return nil, errors.New("signer is nil")
+	}
+	if method.ID.Empty() {
+		return nil, errors.New("verification method has no ID")
+	}
+	alg, err := signatureAlgorithm(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	expectedThumbprint, err := method.JWKThumbprint()
+	if err != nil {
+		return nil, fmt.Errorf("invalid verification method: %w", err)
+	}
+	actualThumbprint, err := did.JWKThumbprint(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	if expectedThumbprint != actualThumbprint {
+		return nil, fmt.Errorf("public key of signer does not match verification method: %s", method.ID)
+	}
+	keyID := method.ID.String()
+	return func(_ context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		token := jwt.New()
+		for key, value := range claims {
+			if err := token.Set(key, value); err != nil {
+				return "", fmt.Errorf("invalid claim %s: %w", key, err)
+			}
+		}
+		protectedHeaders := jws.NewHeaders()
+		for key, value := range headers {
+			if err := protectedHeaders.Set(key, value); err != nil {
+				return "", fmt.Errorf("invalid header %s: %w", key, err)
+			}
+		}
+		if err := protectedHeaders.Set(jws.KeyIDKey, keyID); err != nil {
+			return "", err
+		}
+		signed, err := jwt.Sign(token, jwt.WithKey(alg, signer, jws.WithProtectedHeaders(protectedHeaders)))
+		if err != nil {
+			return "", err
+		}
+		return string(signed), nil
+	}, nil
+}
+
+// signatureAlgorithm returns the JWS algorithm to use for the given public key.
+func signatureAlgorithm(key crypto.PublicKey) (jwa.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return jwa.EdDSA(), nil
+	case *rsa.PublicKey:
+		return jwa.PS256(), nil
+	case *ecdsa.PublicKey:
+		switch k.Curve.Params().Name {
+		case "P-256":
+			return jwa.ES256(), nil
+		case "P-384":
+			return jwa.ES384(), nil
+		case "P-521":
+			return jwa.ES512(), nil
+		case "secp256k1":
+			return jwa.ES256K(), nil
+		}
+		return jwa.EmptySignatureAlgorithm(), fmt.Errorf("unsupported elliptic curve: %s", k.Curve.Params().Name)
+	}
+	return jwa.EmptySignatureAlgorithm(), fmt.Errorf("unsupported key type: %T", key)
+}
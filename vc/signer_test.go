@@ -0,0 +1,129 @@
+package vc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jws"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJWTSigner(t *testing.T) {
+	issuerDID := did.MustParseDID("did:example:issuer")
+	keyID := did.MustParseDIDURL("did:example:issuer#key-1")
+	template := VerifiableCredential{
+		Context:      []ssi.URI{VCContextV1URI()},
+		Type:         []ssi.URI{VerifiableCredentialTypeV1URI()},
+		Issuer:       NewIdentifier(issuerDID.URI()),
+		IssuanceDate: time.Now().Truncate(time.Second),
+		CredentialSubject: []map[string]any{
+			{"id": "did:example:subject"},
+		},
+	}
+	edPublicKey, edPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	p521Key, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	secp256k1Key, _ := secp256k1.GeneratePrivateKey()
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	testCases := []struct {
+		name        string
+		signer      crypto.Signer
+		publicKey   crypto.PublicKey
+		keyType     ssi.KeyType
+		expectedAlg jwa.SignatureAlgorithm
+	}{
+		{name: "Ed25519", signer: edPrivateKey, publicKey: edPublicKey, keyType: ssi.ED25519VerificationKey2020, expectedAlg: jwa.EdDSA()},
+		{name: "P-256", signer: p256Key, publicKey: &p256Key.PublicKey, keyType: ssi.JsonWebKey2020, expectedAlg: jwa.ES256()},
+		{name: "P-384", signer: p384Key, publicKey: &p384Key.PublicKey, keyType: ssi.Multikey, expectedAlg: jwa.ES384()},
+		{name: "P-521", signer: p521Key, publicKey: &p521Key.PublicKey, keyType: ssi.JsonWebKey2020, expectedAlg: jwa.ES512()},
+		{name: "secp256k1", signer: secp256k1Key.ToECDSA(), publicKey: secp256k1Key.PubKey().ToECDSA(), keyType: ssi.ECDSASECP256K1VerificationKey2019, expectedAlg: jwa.ES256K()},
+		{name: "RSA", signer: rsaKey, publicKey: &rsaKey.PublicKey, keyType: ssi.JsonWebKey2020, expectedAlg: jwa.PS256()},
+		// keys that can't be exported (e.g. in an HSM or KMS) only implement crypto.Signer
+		{name: "non-exportable P-256", signer: opaqueSigner{p256Key}, publicKey: &p256Key.PublicKey, keyType: ssi.JsonWebKey2020, expectedAlg: jwa.ES256()},
+		{name: "non-exportable Ed25519", signer: opaqueSigner{edPrivateKey}, publicKey: edPublicKey, keyType: ssi.ED25519VerificationKey2020, expectedAlg: jwa.EdDSA()},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			method, err := did.NewVerificationMethod(keyID, testCase.keyType, issuerDID, testCase.publicKey)
+			require.NoError(t, err)
+			signer, err := NewJWTSigner(testCase.signer, *method)
+			require.NoError(t, err)
+
+			credential, err := CreateJWTVerifiableCredential(context.Background(), template, signer)
+
+			require.NoError(t, err)
+			message, err := jws.Parse([]byte(credential.Raw()))
+			require.NoError(t, err)
+			headers := message.Signatures()[0].ProtectedHeaders()
+			kid, _ := headers.KeyID()
+			assert.Equal(t, keyID.String(), kid)
+			alg, _ := headers.Algorithm()
+			assert.Equal(t, testCase.expectedAlg, alg)
+			typ, _ := headers.Type()
+			assert.Equal(t, "JWT", typ)
+			_, err = jws.Verify([]byte(credential.Raw()), jws.WithKey(testCase.expectedAlg, testCase.publicKey))
+			assert.NoError(t, err)
+			assert.Equal(t, template.IssuanceDate.UTC(), credential.IssuanceDate.UTC())
+		})
+	}
+	t.Run("presentation", func(t *testing.T) {
+		method, _ := did.NewVerificationMethod(keyID, ssi.JsonWebKey2020, issuerDID, &p256Key.PublicKey)
+		signer, err := NewJWTSigner(p256Key, *method)
+		require.NoError(t, err)
+
+		presentation, err := CreateJWTVerifiablePresentation(context.Background(), issuerDID.URI(), nil, PresentationOptions{}, signer)
+
+		require.NoError(t, err)
+		_, err = jws.Verify([]byte(presentation.Raw()), jws.WithKey(jwa.ES256(), &p256Key.PublicKey))
+		assert.NoError(t, err)
+	})
+	t.Run("signer doesn't match verification method", func(t *testing.T) {
+		method, _ := did.NewVerificationMethod(keyID, ssi.JsonWebKey2020, issuerDID, &p384Key.PublicKey)
+		_, err := NewJWTSigner(p256Key, *method)
+		assert.EqualError(t, err, "public key of signer does not match verification method: did:example:issuer#key-1")
+	})
+	t.Run("verification method without key material", func(t *testing.T) {
+		_, err := NewJWTSigner(p256Key, did.VerificationMethod{ID: keyID, Type: ssi.JsonWebKey2020})
+		assert.EqualError(t, err, "invalid verification method: missing publicKeyJwk")
+	})
+	t.Run("verification method without ID", func(t *testing.T) {
+		_, err := NewJWTSigner(p256Key, did.VerificationMethod{})
+		assert.EqualError(t, err, "verification method has no ID")
+	})
+	t.Run("nil signer", func(t *testing.T) {
+		_, err := NewJWTSigner(nil, did.VerificationMethod{})
+		assert.EqualError(t, err, "signer is nil")
+	})
+	t.Run("unsupported curve", func(t *testing.T) {
+		p224Key, _ := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+		_, err := NewJWTSigner(p224Key, did.VerificationMethod{ID: keyID})
+		assert.EqualError(t, err, "unsupported elliptic curve: P-224")
+	})
+}
+
+// opaqueSigner hides the type of the wrapped private key, like signers of keys that can't be exported (e.g. in an HSM or KMS).
+type opaqueSigner struct {
+	signer crypto.Signer
+}
+
+func (o opaqueSigner) Public() crypto.PublicKey {
+	return o.signer.Public()
+}
+
+func (o opaqueSigner) Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return o.signer.Sign(random, digest, opts)
+}
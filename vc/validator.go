@@ -0,0 +1,217 @@
+package vc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	ssi "github.com/nuts-foundation/go-did"
+)
+
+// ErrCredentialInvalid indicates credential validation failed
+var ErrCredentialInvalid = validationError{subject: "credential"}
+
+// ErrPresentationInvalid indicates presentation validation failed
+var ErrPresentationInvalid = validationError{subject: "presentation"}
+
+// ErrInvalidContext indicates the `@context` of the credential or presentation is invalid
+var ErrInvalidContext = errors.New("invalid context")
+
+// ErrInvalidID indicates the `id` of the credential or presentation is invalid
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrInvalidType indicates the `type` of the credential or presentation is invalid
+var ErrInvalidType = errors.New("invalid type")
+
+// ErrInvalidIssuer indicates the `issuer` of the credential is invalid
+var ErrInvalidIssuer = errors.New("invalid issuer")
+
+// ErrInvalidIssuanceDate indicates the `issuanceDate` of the credential is invalid or missing
+var ErrInvalidIssuanceDate = errors.New("invalid issuanceDate")
+
+// ErrInvalidExpirationDate indicates the `expirationDate` of the credential is invalid (e.g. it lies before the issuance date)
+var ErrInvalidExpirationDate = errors.New("invalid expirationDate")
+
+// ErrInvalidValidUntil indicates the `validUntil` of a VCDM 2.0 credential is invalid (e.g. it lies before `validFrom`)
+var ErrInvalidValidUntil = errors.New("invalid validUntil")
+
+// ErrInvalidCredentialSubject indicates the `credentialSubject` of the credential is invalid or missing
+var ErrInvalidCredentialSubject = errors.New("invalid credentialSubject")
+
+// ErrInvalidHolder indicates the `holder` of the presentation is invalid
+var ErrInvalidHolder = errors.New("invalid holder")
+
+// ErrUndefinedProperty indicates the credential uses a property that is not defined by its data model version (e.g. `issuanceDate` in VCDM 2.0). It is reported as warning.
+var ErrUndefinedProperty = errors.New("undefined property")
+
+// Validator defines functions for validating a Verifiable Credential.
+type Validator interface {
+	// Validate validates a credential. It returns the first validation error it finds wrapped in ErrCredentialInvalid.
+	Validate(credential VerifiableCredential) error
+}
+
+// ReportingValidator is a Validator that can also report all its findings, instead of only the first error.
+type ReportingValidator interface {
+	Validator
+	// Report validates a credential and returns all findings, including warnings.
+	Report(credential VerifiableCredential) ssi.ValidationReport
+}
+
+// PresentationValidator defines functions for validating a Verifiable Presentation.
+type PresentationValidator interface {
+	// Validate validates a presentation. It returns the first validation error it finds wrapped in ErrPresentationInvalid.
+	Validate(presentation VerifiablePresentation) error
+}
+
+// ReportingPresentationValidator is a PresentationValidator that can also report all its findings, instead of only the first error.
+type ReportingPresentationValidator interface {
+	PresentationValidator
+	// Report validates a presentation and returns all findings, including warnings.
+	Report(presentation VerifiablePresentation) ssi.ValidationReport
+}
+
+// W3CSpecValidator validates a credential according to the W3C Verifiable Credentials Data Model,
+// version 1.1 (https://www.w3.org/TR/vc-data-model/) or 2.0 (https://www.w3.org/TR/vc-data-model-2.0/) depending on its first `@context`.
+type W3CSpecValidator struct {
+}
+
+func (w W3CSpecValidator) Validate(credential VerifiableCredential) error {
+	return firstError(ErrCredentialInvalid, w.Report(credential))
+}
+
+// Report validates the credential and returns all findings.
+// Use of properties that are not defined by VCDM 2.0 (issuanceDate, expirationDate) in a VCDM 2.0 credential is reported as warning,
+// as is use of validFrom and validUntil in a VCDM 1.1 credential.
+func (w W3CSpecValidator) Report(credential VerifiableCredential) ssi.ValidationReport {
+	result := ssi.ValidationReport{}
+	version := reportContext(credential.Context, &result)
+	reportID(credential.ID, &result)
+	if !containsType(credential.Type, VerifiableCredentialType) {
+		result.AddError(ssi.JSONPointer(typeKey), ErrInvalidType, "type must contain "+VerifiableCredentialType)
+	}
+	// issuer
+	if credential.Issuer.String() == "" {
+		result.AddError(ssi.JSONPointer("issuer"), ErrInvalidIssuer, "issuer is required")
+	} else if credential.Issuer.ID().Scheme == "" {
+		result.AddError(ssi.JSONPointer("issuer"), ErrInvalidIssuer, "issuer must be a URI: "+credential.Issuer.String())
+	}
+	// issuanceDate, expirationDate, validFrom and validUntil
+	switch version {
+	case DataModelV1:
+		if credential.IssuanceDate.IsZero() {
+			result.AddError(ssi.JSONPointer("issuanceDate"), ErrInvalidIssuanceDate, "issuanceDate is required")
+		}
+		if credential.ValidFrom != nil {
+			result.AddWarning(ssi.JSONPointer("validFrom"), ErrUndefinedProperty, "validFrom is not defined in VCDM 1.1")
+		}
+		if credential.ValidUntil != nil {
+			result.AddWarning(ssi.JSONPointer("validUntil"), ErrUndefinedProperty, "validUntil is not defined in VCDM 1.1")
+		}
+	case DataModelV2:
+		if !credential.IssuanceDate.IsZero() {
+			result.AddWarning(ssi.JSONPointer("issuanceDate"), ErrUndefinedProperty, "issuanceDate is not defined in VCDM 2.0")
+		}
+		if credential.ExpirationDate != nil {
+			result.AddWarning(ssi.JSONPointer("expirationDate"), ErrUndefinedProperty, "expirationDate is not defined in VCDM 2.0")
+		}
+	}
+	if credential.ExpirationDate != nil && !credential.IssuanceDate.IsZero() && credential.ExpirationDate.Before(credential.IssuanceDate) {
+		result.AddError(ssi.JSONPointer("expirationDate"), ErrInvalidExpirationDate, "expirationDate must not be before issuanceDate")
+	}
+	if credential.ValidFrom != nil && credential.ValidUntil != nil && credential.ValidUntil.Before(*credential.ValidFrom) {
+		result.AddError(ssi.JSONPointer("validUntil"), ErrInvalidValidUntil, "validUntil must not be before validFrom")
+	}
+	// credentialSubject
+	if len(credential.CredentialSubject) == 0 {
+		result.AddError(ssi.JSONPointer(credentialSubjectKey), ErrInvalidCredentialSubject, "credentialSubject is required")
+	}
+	for i, subject := range credential.CredentialSubject {
+		if len(subject) == 0 {
+			result.AddError(ssi.JSONPointer(credentialSubjectKey, i), ErrInvalidCredentialSubject, "credentialSubject must not be empty")
+		}
+	}
+	return result
+}
+
+// W3CPresentationSpecValidator validates a presentation according to the W3C Verifiable Credentials Data Model,
+// version 1.1 or 2.0 depending on its first `@context`. The credentials in the presentation are validated using W3CSpecValidator.
+type W3CPresentationSpecValidator struct {
+}
+
+func (w W3CPresentationSpecValidator) Validate(presentation VerifiablePresentation) error {
+	return firstError(ErrPresentationInvalid, w.Report(presentation))
+}
+
+// Report validates the presentation and its credentials and returns all findings.
+// Findings of credentials are reported with a JSON pointer relative to the presentation (e.g. /verifiableCredential/0/issuer).
+func (w W3CPresentationSpecValidator) Report(presentation VerifiablePresentation) ssi.ValidationReport {
+	result := ssi.ValidationReport{}
+	reportContext(presentation.Context, &result)
+	reportID(presentation.ID, &result)
+	if !containsType(presentation.Type, VerifiablePresentationType) {
+		result.AddError(ssi.JSONPointer(typeKey), ErrInvalidType, "type must contain "+VerifiablePresentationType)
+	}
+	if presentation.Holder != nil && presentation.Holder.ID().Scheme == "" {
+		result.AddError(ssi.JSONPointer("holder"), ErrInvalidHolder, "holder must be a URI: "+presentation.Holder.String())
+	}
+	for i, credential := range presentation.VerifiableCredential {
+		prefix := ssi.JSONPointer(verifiableCredentialKey, i)
+		for _, finding := range (W3CSpecValidator{}).Report(credential).Findings {
+			finding.Pointer = prefix + finding.Pointer
+			result.Findings = append(result.Findings, finding)
+		}
+	}
+	return result
+}
+
+// reportContext validates the `@context` of a credential or presentation and returns the data model version it indicates.
+func reportContext(context []ssi.URI, report *ssi.ValidationReport) DataModelVersion {
+	version := dataModelVersion(context)
+	if version == UnknownDataModelVersion {
+		report.AddError(ssi.JSONPointer(contextKey, 0), ErrInvalidContext, fmt.Sprintf("first @context must be %s or %s", VCContextV1, VCContextV2))
+	}
+	return version
+}
+
+func reportID(id *ssi.URI, report *ssi.ValidationReport) {
+	if id != nil && id.Scheme == "" {
+		report.AddError(ssi.JSONPointer("id"), ErrInvalidID, "id must be a URI: "+id.String())
+	}
+}
+
+func containsType(types []ssi.URI, expected string) bool {
+	for _, curr := range types {
+		if curr.String() == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// firstError returns the code of the first error finding wrapped in the given validationError, or nil if there are no errors.
+func firstError(wrapper validationError, report ssi.ValidationReport) error {
+	errs := report.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	wrapper.cause = errs[0].Code
+	return wrapper
+}
+
+type validationError struct {
+	subject string
+	cause   error
+}
+
+func (v validationError) Unwrap() error {
+	return v.cause
+}
+
+func (v validationError) Is(err error) bool {
+	other, is := err.(validationError)
+	return is && other.subject == v.subject
+}
+
+func (v validationError) Error() string {
+	return fmt.Sprintf("%s validation failed: %v", strings.ToUpper(v.subject[:1])+v.subject[1:], v.cause)
+}
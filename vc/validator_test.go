@@ -0,0 +1,246 @@
+package vc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestW3CSpecValidator(t *testing.T) {
+	findings := func(report ssi.ValidationReport) []string {
+		var result []string
+		for _, finding := range report.Findings {
+			result = append(result, finding.String())
+		}
+		return result
+	}
+	t.Run("ok - VCDM 1.1", func(t *testing.T) {
+		assert.NoError(t, W3CSpecValidator{}.Validate(credentialV1()))
+		assert.Empty(t, W3CSpecValidator{}.Report(credentialV1()).Findings)
+	})
+	t.Run("ok - VCDM 2.0", func(t *testing.T) {
+		assert.NoError(t, W3CSpecValidator{}.Validate(credentialV2()))
+		assert.Empty(t, W3CSpecValidator{}.Report(credentialV2()).Findings)
+	})
+	t.Run("ok - parsed JWT credential", func(t *testing.T) {
+		credential, err := ParseVerifiableCredential(jwtCredential)
+		require.NoError(t, err)
+
+		assert.NoError(t, W3CSpecValidator{}.Validate(*credential))
+	})
+	t.Run("first context must be a VC context", func(t *testing.T) {
+		input := credentialV1()
+		input.Context = []ssi.URI{ssi.MustParseURI("https://example.com/context"), VCContextV1URI()}
+
+		err := W3CSpecValidator{}.Validate(input)
+
+		assertIsError(t, ErrCredentialInvalid, ErrInvalidContext, err)
+		assert.EqualError(t, err, "Credential validation failed: invalid context")
+		assert.Equal(t, []string{"error at /@context/0: first @context must be https://www.w3.org/2018/credentials/v1 or https://www.w3.org/ns/credentials/v2"}, findings(W3CSpecValidator{}.Report(input)))
+	})
+	t.Run("missing context", func(t *testing.T) {
+		input := credentialV1()
+		input.Context = nil
+
+		assertIsError(t, ErrCredentialInvalid, ErrInvalidContext, W3CSpecValidator{}.Validate(input))
+	})
+	t.Run("id must be a URI", func(t *testing.T) {
+		input := credentialV1()
+		input.ID = &ssi.URI{}
+		input.ID.Path = "123"
+
+		assertIsError(t, ErrCredentialInvalid, ErrInvalidID, W3CSpecValidator{}.Validate(input))
+	})
+	t.Run("type must contain VerifiableCredential", func(t *testing.T) {
+		input := credentialV1()
+		input.Type = []ssi.URI{ssi.MustParseURI("ExampleCredential")}
+
+		assertIsError(t, ErrCredentialInvalid, ErrInvalidType, W3CSpecValidator{}.Validate(input))
+	})
+	t.Run("issuer", func(t *testing.T) {
+		t.Run("missing", func(t *testing.T) {
+			input := credentialV1()
+			input.Issuer = IdentifierOrObject{}
+
+			assertIsError(t, ErrCredentialInvalid, ErrInvalidIssuer, W3CSpecValidator{}.Validate(input))
+		})
+		t.Run("not a URI", func(t *testing.T) {
+			input := credentialV2()
+			input.Issuer = NewIdentifier(ssi.MustParseURI("issuer"))
+
+			assert.Equal(t, []string{"error at /issuer: issuer must be a URI: issuer"}, findings(W3CSpecValidator{}.Report(input)))
+		})
+	})
+	t.Run("issuanceDate", func(t *testing.T) {
+		t.Run("required for VCDM 1.1", func(t *testing.T) {
+			input := credentialV1()
+			input.IssuanceDate = time.Time{}
+
+			assertIsError(t, ErrCredentialInvalid, ErrInvalidIssuanceDate, W3CSpecValidator{}.Validate(input))
+		})
+		t.Run("not defined in VCDM 2.0", func(t *testing.T) {
+			input := credentialV2()
+			input.IssuanceDate = time.Now()
+			expirationDate := input.IssuanceDate.Add(time.Hour)
+			input.ExpirationDate = &expirationDate
+
+			report := W3CSpecValidator{}.Report(input)
+
+			assert.True(t, report.Valid())
+			assert.Equal(t, []string{
+				"warning at /issuanceDate: issuanceDate is not defined in VCDM 2.0",
+				"warning at /expirationDate: expirationDate is not defined in VCDM 2.0",
+			}, findings(report))
+			assert.ErrorIs(t, report.Warnings()[0].Code, ErrUndefinedProperty)
+		})
+	})
+	t.Run("expirationDate before issuanceDate", func(t *testing.T) {
+		input := credentialV1()
+		expirationDate := input.IssuanceDate.Add(-time.Hour)
+		input.ExpirationDate = &expirationDate
+
+		assertIsError(t, ErrCredentialInvalid, ErrInvalidExpirationDate, W3CSpecValidator{}.Validate(input))
+	})
+	t.Run("validFrom and validUntil", func(t *testing.T) {
+		t.Run("ok - VCDM 2.0", func(t *testing.T) {
+			input := credentialV2()
+			validFrom := time.Now()
+			validUntil := validFrom.Add(time.Hour)
+			input.ValidFrom = &validFrom
+			input.ValidUntil = &validUntil
+
+			assert.Empty(t, W3CSpecValidator{}.Report(input).Findings)
+		})
+		t.Run("validUntil before validFrom", func(t *testing.T) {
+			input := credentialV2()
+			validFrom := time.Now()
+			validUntil := validFrom.Add(-time.Hour)
+			input.ValidFrom = &validFrom
+			input.ValidUntil = &validUntil
+
+			assertIsError(t, ErrCredentialInvalid, ErrInvalidValidUntil, W3CSpecValidator{}.Validate(input))
+		})
+		t.Run("not defined in VCDM 1.1", func(t *testing.T) {
+			input := credentialV1()
+			input.ValidFrom = &input.IssuanceDate
+			input.ValidUntil = &input.IssuanceDate
+
+			report := W3CSpecValidator{}.Report(input)
+
+			assert.True(t, report.Valid())
+			assert.Equal(t, []string{
+				"warning at /validFrom: validFrom is not defined in VCDM 1.1",
+				"warning at /validUntil: validUntil is not defined in VCDM 1.1",
+			}, findings(report))
+		})
+	})
+	t.Run("credentialSubject", func(t *testing.T) {
+		t.Run("missing", func(t *testing.T) {
+			input := credentialV1()
+			input.CredentialSubject = nil
+
+			assertIsError(t, ErrCredentialInvalid, ErrInvalidCredentialSubject, W3CSpecValidator{}.Validate(input))
+		})
+		t.Run("empty", func(t *testing.T) {
+			input := credentialV1()
+			input.CredentialSubject = append(input.CredentialSubject, map[string]any{})
+
+			assert.Equal(t, []string{"error at /credentialSubject/1: credentialSubject must not be empty"}, findings(W3CSpecValidator{}.Report(input)))
+		})
+	})
+	t.Run("reports all findings", func(t *testing.T) {
+		report := W3CSpecValidator{}.Report(VerifiableCredential{Context: []ssi.URI{VCContextV1URI()}})
+
+		assert.Equal(t, []string{
+			"error at /type: type must contain VerifiableCredential",
+			"error at /issuer: issuer is required",
+			"error at /issuanceDate: issuanceDate is required",
+			"error at /credentialSubject: credentialSubject is required",
+		}, findings(report))
+	})
+}
+
+func TestW3CPresentationSpecValidator(t *testing.T) {
+	presentation := func() VerifiablePresentation {
+		holder := NewIdentifier(ssi.MustParseURI("did:example:holder"))
+		return VerifiablePresentation{
+			Context:              []ssi.URI{VCContextV2URI()},
+			Type:                 []ssi.URI{VerifiablePresentationTypeV1URI()},
+			Holder:               &holder,
+			VerifiableCredential: []VerifiableCredential{credentialV1(), credentialV2()},
+		}
+	}
+	t.Run("ok", func(t *testing.T) {
+		assert.NoError(t, W3CPresentationSpecValidator{}.Validate(presentation()))
+		assert.Empty(t, W3CPresentationSpecValidator{}.Report(presentation()).Findings)
+	})
+	t.Run("ok - parsed JWT presentation", func(t *testing.T) {
+		parsed, err := ParseVerifiablePresentation(jwtPresentation)
+		require.NoError(t, err)
+
+		assert.NoError(t, W3CPresentationSpecValidator{}.Validate(*parsed))
+	})
+	t.Run("first context must be a VC context", func(t *testing.T) {
+		input := presentation()
+		input.Context = nil
+
+		err := W3CPresentationSpecValidator{}.Validate(input)
+
+		assertIsError(t, ErrPresentationInvalid, ErrInvalidContext, err)
+		assert.EqualError(t, err, "Presentation validation failed: invalid context")
+		assert.NotErrorIs(t, err, ErrCredentialInvalid)
+	})
+	t.Run("type must contain VerifiablePresentation", func(t *testing.T) {
+		input := presentation()
+		input.Type = nil
+
+		assertIsError(t, ErrPresentationInvalid, ErrInvalidType, W3CPresentationSpecValidator{}.Validate(input))
+	})
+	t.Run("holder must be a URI", func(t *testing.T) {
+		input := presentation()
+		holder := NewIdentifier(ssi.MustParseURI("holder"))
+		input.Holder = &holder
+
+		assertIsError(t, ErrPresentationInvalid, ErrInvalidHolder, W3CPresentationSpecValidator{}.Validate(input))
+	})
+	t.Run("credentials are validated", func(t *testing.T) {
+		input := presentation()
+		input.VerifiableCredential[1].Issuer = IdentifierOrObject{}
+
+		report := W3CPresentationSpecValidator{}.Report(input)
+
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, "error at /verifiableCredential/1/issuer: issuer is required", report.Findings[0].String())
+		assertIsError(t, ErrPresentationInvalid, ErrInvalidIssuer, W3CPresentationSpecValidator{}.Validate(input))
+	})
+}
+
+func assertIsError(t *testing.T, expectedWrapper error, expected error, actual error) {
+	t.Helper()
+	require.Error(t, actual)
+	assert.ErrorIs(t, actual, expectedWrapper)
+	assert.True(t, errors.Is(actual, expected), "expected %v, got %v", expected, actual)
+}
+
+func credentialV1() VerifiableCredential {
+	return VerifiableCredential{
+		Context:           []ssi.URI{VCContextV1URI()},
+		Type:              []ssi.URI{VerifiableCredentialTypeV1URI()},
+		Issuer:            NewIdentifier(ssi.MustParseURI("did:example:issuer")),
+		IssuanceDate:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		CredentialSubject: []map[string]any{{"id": "did:example:subject"}},
+	}
+}
+
+func credentialV2() VerifiableCredential {
+	return VerifiableCredential{
+		Context:           []ssi.URI{VCContextV2URI()},
+		Type:              []ssi.URI{VerifiableCredentialTypeV1URI()},
+		Issuer:            NewIdentifier(ssi.MustParseURI("did:example:issuer")),
+		CredentialSubject: []map[string]any{{"id": "did:example:subject"}},
+	}
+}
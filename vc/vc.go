@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 
 	ssi "github.com/nuts-foundation/go-did"
 
+	"github.com/nuts-foundation/go-did/internal/clone"
 	"github.com/nuts-foundation/go-did/internal/marshal"
 )
 
@@ -38,6 +40,42 @@ func VCContextV1URI() ssi.URI {
 	}
 }
 
+// VCContextV2 is the context required for every credential and presentation conforming to the Verifiable Credentials Data Model 2.0
+const VCContextV2 = "https://www.w3.org/ns/credentials/v2"
+
+// VCContextV2URI returns 'https://www.w3.org/ns/credentials/v2' as URI
+func VCContextV2URI() ssi.URI {
+	return ssi.MustParseURI(VCContextV2)
+}
+
+// DataModelVersion is the version of the Verifiable Credentials Data Model a credential or presentation conforms to.
+type DataModelVersion int
+
+const (
+	// UnknownDataModelVersion indicates the first `@context` is not a Verifiable Credentials context.
+	UnknownDataModelVersion DataModelVersion = 0
+	// DataModelV1 indicates the Verifiable Credentials Data Model 1.1 (https://www.w3.org/TR/vc-data-model/).
+	DataModelV1 DataModelVersion = 1
+	// DataModelV2 indicates the Verifiable Credentials Data Model 2.0 (https://www.w3.org/TR/vc-data-model-2.0/).
+	DataModelV2 DataModelVersion = 2
+)
+
+// dataModelVersion returns the version of the Verifiable Credentials Data Model indicated by the first context,
+// or UnknownDataModelVersion if it isn't a VC context.
+func dataModelVersion(context []ssi.URI) DataModelVersion {
+	if len(context) == 0 {
+		return UnknownDataModelVersion
+	}
+	switch context[0].String() {
+	case VCContextV1:
+		return DataModelV1
+	case VCContextV2:
+		return DataModelV2
+	default:
+		return UnknownDataModelVersion
+	}
+}
+
 const (
 	// JSONLDCredentialProofFormat is the format for JSON-LD based credentials.
 	JSONLDCredentialProofFormat string = "ldp_vc"
@@ -78,19 +116,28 @@ func parseJWTCredential(raw string) (*VerifiableCredential, error) {
 			return nil, fmt.Errorf("invalid JWT 'vc' claim: %w", err)
 		}
 	}
+	v2 := result.Version() == DataModelV2
 	// parse exp
 	if exp, ok := token.Expiration(); ok {
-		result.ExpirationDate = &exp
+		if v2 {
+			result.ValidUntil = &exp
+		} else {
+			result.ExpirationDate = &exp
+		}
 	}
 	// parse iss
 	if iss, err := parseURIClaim(token, jwt.IssuerKey); err != nil {
 		return nil, err
-	} else if iss != nil {
-		result.Issuer = *iss
+	} else if iss != nil && result.Issuer.ID().String() != iss.String() {
+		result.Issuer = NewIdentifier(*iss)
 	}
 	// parse nbf
 	if nbf, ok := token.NotBefore(); ok {
-		result.IssuanceDate = nbf
+		if v2 {
+			result.ValidFrom = &nbf
+		} else {
+			result.IssuanceDate = nbf
+		}
 	}
 	// parse sub
 	if sub, ok := token.Subject(); ok && sub != "" {
@@ -112,22 +159,54 @@ func parseJWTCredential(raw string) (*VerifiableCredential, error) {
 
 func parseJSONLDCredential(raw string) (*VerifiableCredential, error) {
 	type Alias VerifiableCredential
-	normalizedVC, err := marshal.NormalizeDocument([]byte(raw), pluralContext, marshal.Plural(typeKey), marshal.Plural(credentialSubjectKey), marshal.Plural(credentialStatusKey), marshal.Plural(proofKey))
+	normalizedVC, err := marshal.NormalizeDocument([]byte(raw), pluralContext, marshal.Plural(typeKey), marshal.Plural(credentialSubjectKey), marshal.Plural(credentialStatusKey), marshal.Plural(credentialSchemaKey), marshal.Plural(relatedResourceKey),
+		marshal.Plural(refreshServiceKey), marshal.Plural(termsOfUseKey), marshal.Plural(evidenceKey), marshal.Plural(proofKey))
 	if err != nil {
 		return nil, err
 	}
+	// Properties that don't have a designated field, and optional properties that can't be decoded into their field, are retained as AdditionalProperties
+	var properties, original map[string]json.RawMessage
+	if err = json.Unmarshal(normalizedVC, &properties); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(raw), &original); err != nil {
+		return nil, err
+	}
+	// VCDM 2.0 properties are only parsed strictly for VCDM 2.0 credentials, since other versions might define them differently
+	var context []ssi.URI
+	_ = json.Unmarshal(properties[contextKey], &context)
+	isV2 := dataModelVersion(context) == DataModelV2
+	additionalProperties := map[string]interface{}{}
+	for key, value := range properties {
+		fieldType, isField := credentialProperties[key]
+		lenient := lenientProperties[key] || (v2Properties[key] && !isV2)
+		if isField && (!lenient || decodes(value, fieldType)) {
+			continue
+		}
+		var originalValue interface{}
+		if err = json.Unmarshal(original[key], &originalValue); err != nil {
+			return nil, err
+		}
+		additionalProperties[key] = originalValue
+		delete(properties, key)
+	}
+	normalizedVC, _ = json.Marshal(properties)
 	alias := Alias{}
 	err = json.Unmarshal(normalizedVC, &alias)
 	if err != nil {
 		return nil, err
 	}
+	if len(additionalProperties) > 0 {
+		alias.AdditionalProperties = additionalProperties
+	}
 	alias.format = JSONLDCredentialProofFormat
 	alias.raw = raw
 	result := VerifiableCredential(alias)
 	return &result, err
 }
 
-// VerifiableCredential represents a credential as defined by the Verifiable Credentials Data Model 1.0 specification (https://www.w3.org/TR/vc-data-model/).
+// VerifiableCredential represents a credential as defined by the Verifiable Credentials Data Model 1.1 (https://www.w3.org/TR/vc-data-model/)
+// or 2.0 (https://www.w3.org/TR/vc-data-model-2.0/) specification. Use Version to find out which one it conforms to.
 type VerifiableCredential struct {
 	// Context defines the json-ld context to dereference the URIs
 	Context []ssi.URI `json:"@context"`
@@ -135,24 +214,86 @@ type VerifiableCredential struct {
 	ID *ssi.URI `json:"id,omitempty"`
 	// Type holds multiple types for a credential. A credential must always have the 'VerifiableCredential' type.
 	Type []ssi.URI `json:"type"`
-	// Issuer refers to the party that issued the credential
-	Issuer ssi.URI `json:"issuer"`
+	// Issuer refers to the party that issued the credential. It is either a URI or an object with an `id`.
+	Issuer IdentifierOrObject `json:"issuer"`
 	// IssuanceDate is a rfc3339 formatted datetime.
 	IssuanceDate time.Time `json:"issuanceDate"`
 	// ExpirationDate is a rfc3339 formatted datetime. It is optional
 	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+	// ValidFrom is the VCDM 2.0 replacement of IssuanceDate, it is a rfc3339 formatted datetime. It is optional
+	ValidFrom *time.Time `json:"validFrom,omitempty"`
+	// ValidUntil is the VCDM 2.0 replacement of ExpirationDate, it is a rfc3339 formatted datetime. It is optional
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+	// Name is the (VCDM 2.0) name of the credential, optionally in multiple languages. It is optional
+	Name LanguageValues `json:"name,omitempty"`
+	// Description is the (VCDM 2.0) description of the credential, optionally in multiple languages. It is optional
+	Description LanguageValues `json:"description,omitempty"`
 	// CredentialStatus holds information on how the credential can be revoked. It must be extracted using the UnmarshalCredentialStatus method and a custom type.
 	CredentialStatus []any `json:"credentialStatus,omitempty"`
+	// CredentialSchema refers to the schemas the credential conforms to. It is optional. Use JSONSchemaValidator to validate a credential against its schemas.
+	CredentialSchema []CredentialSchema `json:"credentialSchema,omitempty"`
+	// RelatedResource holds the (VCDM 2.0) integrity information of resources the credential refers to. It is optional
+	RelatedResource []RelatedResource `json:"relatedResource,omitempty"`
+	// RefreshService holds information on how the holder can refresh the credential. It is optional
+	RefreshService []RefreshService `json:"refreshService,omitempty"`
+	// TermsOfUse holds the terms under which the credential was issued. It is optional
+	TermsOfUse []TermsOfUse `json:"termsOfUse,omitempty"`
+	// Evidence holds the evidence the issuer relied upon when issuing the credential. It is optional
+	Evidence []Evidence `json:"evidence,omitempty"`
 	// CredentialSubject holds the actual data for the credential. It must be extracted using the UnmarshalCredentialSubject method and a custom type.
 	CredentialSubject []map[string]any `json:"credentialSubject"`
 	// Proof contains the cryptographic proof(s). It must be extracted using the Proofs method or UnmarshalProofValue method for non-generic proof fields.
 	Proof []interface{} `json:"proof,omitempty"`
+	// AdditionalProperties holds the top-level properties that don't have a designated field (e.g. properties defined by an extension context).
+	// They are retained when marshalling, but can't override properties that have a designated field.
+	AdditionalProperties map[string]interface{} `json:"-"`
 
 	format string
 	raw    string
 	token  jwt.Token
 }
 
+// credentialProperties contains the JSON property names of the fields of VerifiableCredential, and their types.
+var credentialProperties = func() map[string]reflect.Type {
+	result := map[string]reflect.Type{}
+	credentialType := reflect.TypeOf(VerifiableCredential{})
+	for i := 0; i < credentialType.NumField(); i++ {
+		name, _, _ := strings.Cut(credentialType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			result[name] = credentialType.Field(i).Type
+		}
+	}
+	return result
+}()
+
+// lenientProperties contains the optional properties that are retained as AdditionalProperties when they can't be decoded into their field,
+// instead of failing to parse the credential (e.g. when an extension context defines them differently).
+var lenientProperties = map[string]bool{
+	credentialSchemaKey: true,
+	refreshServiceKey:   true,
+	termsOfUseKey:       true,
+	evidenceKey:         true,
+}
+
+// v2Properties contains the properties introduced by VCDM 2.0, which are parsed leniently (like lenientProperties) for credentials of other versions.
+var v2Properties = map[string]bool{
+	validFromKey:       true,
+	validUntilKey:      true,
+	nameKey:            true,
+	descriptionKey:     true,
+	relatedResourceKey: true,
+}
+
+// decodes returns whether the JSON value can be decoded into a value of the given type.
+func decodes(value json.RawMessage, valueType reflect.Type) bool {
+	return json.Unmarshal(value, reflect.New(valueType).Interface()) == nil
+}
+
+// Version returns the version of the Verifiable Credentials Data Model the credential conforms to, as indicated by its first `@context`.
+func (vc VerifiableCredential) Version() DataModelVersion {
+	return dataModelVersion(vc.Context)
+}
+
 // Format returns the format of the credential (e.g. jwt_vc or ldp_vc).
 func (vc VerifiableCredential) Format() string {
 	return vc.format
@@ -172,13 +313,29 @@ func (vc VerifiableCredential) JWT() jwt.Token {
 	return token
 }
 
+// Clone returns a deep copy of the credential, including the JWT token if the credential was parsed from a JWT.
+func (vc VerifiableCredential) Clone() *VerifiableCredential {
+	result := clone.Of(vc)
+	result.token = vc.JWT()
+	return &result
+}
+
 // ValidAt checks that t is within the validity window of the credential.
 // The skew parameter allows compensating for some clock skew (set to 0 for strict validation).
 // Return true if
-// - t+skew >= IssuanceDate
-// - t-skew <= ExpirationDate
+// - t+skew >= IssuanceDate (ValidFrom for VCDM 2.0 credentials)
+// - t-skew <= ExpirationDate (ValidUntil for VCDM 2.0 credentials)
 // For any value that is missing, the evaluation defaults to true.
 func (vc VerifiableCredential) ValidAt(t time.Time, skew time.Duration) bool {
+	if vc.Version() == DataModelV2 {
+		if vc.ValidFrom != nil && t.Add(skew).Before(*vc.ValidFrom) {
+			return false
+		}
+		if vc.ValidUntil != nil && t.Add(-skew).After(*vc.ValidUntil) {
+			return false
+		}
+		return true
+	}
 	// IssuanceDate is a required field, but will default to the zero value when missing. (when ValidFrom != nil)
 	// t > IssuanceDate
 	if t.Add(skew).Before(vc.IssuanceDate) {
@@ -192,6 +349,19 @@ func (vc VerifiableCredential) ValidAt(t time.Time, skew time.Duration) bool {
 	return true
 }
 
+// RelatedResource contains the integrity information of a resource referred to by a VCDM 2.0 credential
+// (https://www.w3.org/TR/vc-data-model-2.0/#integrity-of-related-resources).
+type RelatedResource struct {
+	// ID is the URL of the resource.
+	ID ssi.URI `json:"id"`
+	// DigestSRI is the digest of the resource in Subresource Integrity format (e.g. sha384-...).
+	DigestSRI string `json:"digestSRI,omitempty"`
+	// DigestMultibase is the multibase-encoded multihash digest of the resource.
+	DigestMultibase string `json:"digestMultibase,omitempty"`
+	// MediaType is the expected media type of the resource. It is optional.
+	MediaType string `json:"mediaType,omitempty"`
+}
+
 // CredentialStatus contains the required fields ID and Type, and the raw data for unmarshalling into a custom type.
 type CredentialStatus struct {
 	ID   ssi.URI `json:"id"`
@@ -200,8 +370,8 @@ type CredentialStatus struct {
 }
 
 func (cs *CredentialStatus) UnmarshalJSON(input []byte) error {
-	type alias *CredentialStatus
-	a := alias(cs)
+	type alias CredentialStatus
+	a := (*alias)(cs)
 	err := json.Unmarshal(input, a)
 	if err != nil {
 		return err
@@ -261,10 +431,27 @@ func (vc VerifiableCredential) MarshalJSON() ([]byte, error) {
 	// Must be a JSON-LD credential
 	type alias VerifiableCredential
 	tmp := alias(vc)
-	if data, err := json.Marshal(tmp); err != nil {
+	data, err := json.Marshal(tmp)
+	if err != nil {
 		return nil, err
-	} else {
-		return marshal.NormalizeDocument(data, pluralContext, marshal.Unplural(typeKey), marshal.Unplural(credentialSubjectKey), marshal.Unplural(credentialStatusKey), marshal.Unplural(proofKey))
+	}
+	normalizers := []marshal.Normalizer{pluralContext, marshal.Unplural(typeKey), marshal.Unplural(credentialSubjectKey), marshal.Unplural(credentialStatusKey), marshal.Unplural(credentialSchemaKey), marshal.Unplural(relatedResourceKey),
+		marshal.Unplural(refreshServiceKey), marshal.Unplural(termsOfUseKey), marshal.Unplural(evidenceKey), marshal.Unplural(proofKey), vc.addAdditionalProperties}
+	if vc.Version() == DataModelV2 && vc.IssuanceDate.IsZero() {
+		// issuanceDate is not defined in VCDM 2.0, so don't emit it when it isn't set
+		normalizers = append(normalizers, func(m map[string]interface{}) {
+			delete(m, issuanceDateKey)
+		})
+	}
+	return marshal.NormalizeDocument(data, normalizers...)
+}
+
+// addAdditionalProperties is a marshal.Normalizer that adds AdditionalProperties to the marshalled credential.
+func (vc VerifiableCredential) addAdditionalProperties(credential map[string]interface{}) {
+	for key, value := range vc.AdditionalProperties {
+		if _, exists := credential[key]; !exists {
+			credential[key] = value
+		}
 	}
 }
 
@@ -377,6 +564,7 @@ func (vc VerifiableCredential) ContainsContext(context ssi.URI) bool {
 	return false
 }
 
+// JWTSigner signs the given JWT claims and headers, returning the JWT in compact serialization. Use NewJWTSigner to create one from a crypto.Signer.
 type JWTSigner func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error)
 
 // CreateCredentialOption is a functional option for CreateJWTVerifiableCredential. It can mutate the JWT claims
@@ -400,8 +588,10 @@ func WithCredentialSubjectAsObject() CreateCredentialOption {
 
 // CreateJWTVerifiableCredential creates a JWT Verifiable Credential from the given credential template.
 // For signing the actual JWT it calls the given signer, which must return the created JWT in string format.
-// Note: the signer is responsible for adding the right key claims (e.g. `kid`).
+// Note: the signer is responsible for adding the right key claims (e.g. `kid`), which signers created with NewJWTSigner do.
 // If template.IssuanceDate is the zero value, it defaults to the current time (mapped to both 'nbf' and 'iat' claims).
+// For VCDM 2.0 templates, ValidFrom and ValidUntil are used instead of IssuanceDate and ExpirationDate (which must not be set):
+// 'nbf' is only set if ValidFrom is set, and 'iat' defaults to the current time.
 func CreateJWTVerifiableCredential(ctx context.Context, template VerifiableCredential, signer JWTSigner, options ...CreateCredentialOption) (*VerifiableCredential, error) {
 	subjectDID, err := template.SubjectDID()
 	if err != nil {
@@ -415,26 +605,65 @@ func CreateJWTVerifiableCredential(ctx context.Context, template VerifiableCrede
 		"type":              template.Type,
 		"credentialSubject": template.CredentialSubject,
 	}
-	issuanceDate := template.IssuanceDate
-	if issuanceDate.IsZero() {
-		issuanceDate = time.Now()
-	}
 	claims := map[string]interface{}{
-		jwt.NotBeforeKey: issuanceDate,
-		jwt.IssuedAtKey:  issuanceDate,
-		jwt.IssuerKey:    template.Issuer.String(),
-		jwt.SubjectKey:   subjectDID.String(),
-		"vc":             vcMap,
+		jwt.IssuerKey:  template.Issuer.ID().String(),
+		jwt.SubjectKey: subjectDID.String(),
+		"vc":           vcMap,
+	}
+	expirationDate := template.ExpirationDate
+	if template.Version() == DataModelV2 {
+		if !template.IssuanceDate.IsZero() || template.ExpirationDate != nil {
+			return nil, errors.New("VCDM 2.0 credentials must use ValidFrom and ValidUntil instead of IssuanceDate and ExpirationDate")
+		}
+		claims[jwt.IssuedAtKey] = time.Now()
+		if template.ValidFrom != nil {
+			claims[jwt.NotBeforeKey] = *template.ValidFrom
+			claims[jwt.IssuedAtKey] = *template.ValidFrom
+		}
+		expirationDate = template.ValidUntil
+	} else {
+		issuanceDate := template.IssuanceDate
+		if issuanceDate.IsZero() {
+			issuanceDate = time.Now()
+		}
+		claims[jwt.NotBeforeKey] = issuanceDate
+		claims[jwt.IssuedAtKey] = issuanceDate
 	}
 	if template.ID != nil {
 		claims[jwt.JwtIDKey] = template.ID.String()
 	}
-	if template.ExpirationDate != nil {
-		claims[jwt.ExpirationKey] = *template.ExpirationDate
+	if expirationDate != nil {
+		claims[jwt.ExpirationKey] = *expirationDate
+	}
+	if template.Issuer.IsObject() {
+		// 'iss' can only hold the issuer's ID, so keep the other properties in the 'vc' claim
+		vcMap["issuer"] = template.Issuer
 	}
 	if template.CredentialStatus != nil {
 		vcMap["credentialStatus"] = template.CredentialStatus
 	}
+	if template.CredentialSchema != nil {
+		vcMap["credentialSchema"] = template.CredentialSchema
+	}
+	if template.Name != nil {
+		vcMap["name"] = template.Name
+	}
+	if template.Description != nil {
+		vcMap["description"] = template.Description
+	}
+	if template.RelatedResource != nil {
+		vcMap[relatedResourceKey] = template.RelatedResource
+	}
+	if template.RefreshService != nil {
+		vcMap[refreshServiceKey] = template.RefreshService
+	}
+	if template.TermsOfUse != nil {
+		vcMap[termsOfUseKey] = template.TermsOfUse
+	}
+	if template.Evidence != nil {
+		vcMap[evidenceKey] = template.Evidence
+	}
+	template.addAdditionalProperties(vcMap)
 	for _, opt := range options {
 		if err := opt(claims); err != nil {
 			return nil, err
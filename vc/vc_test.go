@@ -60,6 +60,24 @@ func TestVerifiableCredential_JSONMarshalling(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(string(marshalled), "{"))
 
+		t.Run("VCDM 2.0 omits empty issuanceDate", func(t *testing.T) {
+			validFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			input := VerifiableCredential{
+				Context:         []ssi.URI{VCContextV2URI()},
+				Type:            []ssi.URI{VerifiableCredentialTypeV1URI()},
+				ValidFrom:       &validFrom,
+				Name:            LanguageValues{{Value: "Example"}},
+				RelatedResource: []RelatedResource{{ID: ssi.MustParseURI("https://example.com/logo.png"), DigestSRI: "sha384-abc"}},
+			}
+			actual, err := json.Marshal(input)
+			require.NoError(t, err)
+			asMap := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(actual, &asMap))
+			assert.NotContains(t, asMap, "issuanceDate")
+			assert.Equal(t, "2024-01-01T00:00:00Z", asMap["validFrom"])
+			assert.Equal(t, "Example", asMap["name"])
+			assert.Equal(t, map[string]interface{}{"id": "https://example.com/logo.png", "digestSRI": "sha384-abc"}, asMap["relatedResource"])
+		})
 		t.Run("marshal empty VC", func(t *testing.T) {
 			input := VerifiableCredential{}
 			actual, err := json.Marshal(input)
@@ -119,6 +137,284 @@ func TestParseVerifiableCredential(t *testing.T) {
 		assert.Nil(t, credential.ExpirationDate)
 		assert.Empty(t, credential.IssuanceDate)
 	})
+	t.Run("JSON-LD VCDM 2.0", func(t *testing.T) {
+		credential, err := ParseVerifiableCredential(`{
+		  "@context": ["https://www.w3.org/ns/credentials/v2"],
+		  "type": "VerifiableCredential",
+		  "issuer": "did:example:issuer",
+		  "validFrom": "2024-01-01T00:00:00Z",
+		  "validUntil": "2025-01-01T00:00:00Z",
+		  "name": {"en": "Example", "nl": "Voorbeeld"},
+		  "description": "An example credential",
+		  "relatedResource": {"id": "https://example.com/logo.png", "digestSRI": "sha384-abc", "mediaType": "image/png"},
+		  "credentialSubject": {"id": "did:example:subject"}
+		}`)
+		require.NoError(t, err)
+
+		assert.Equal(t, DataModelV2, credential.Version())
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *credential.ValidFrom)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), *credential.ValidUntil)
+		assert.Equal(t, "Voorbeeld", credential.Name.Get("nl"))
+		assert.Equal(t, "An example credential", credential.Description.Get("en"))
+		require.Len(t, credential.RelatedResource, 1)
+		assert.Equal(t, "https://example.com/logo.png", credential.RelatedResource[0].ID.String())
+		assert.Equal(t, "sha384-abc", credential.RelatedResource[0].DigestSRI)
+		assert.Equal(t, "image/png", credential.RelatedResource[0].MediaType)
+	})
+	t.Run("JSON-LD VCDM 2.0 with invalid name", func(t *testing.T) {
+		_, err := ParseVerifiableCredential(`{
+		  "@context": ["https://www.w3.org/ns/credentials/v2"],
+		  "type": "VerifiableCredential",
+		  "issuer": "did:example:issuer",
+		  "name": 123,
+		  "credentialSubject": {"id": "did:example:subject"}
+		}`)
+		assert.EqualError(t, err, "invalid language value: expected string, value object or language map")
+	})
+	t.Run("JSON-LD VCDM 1.1 with VCDM 2.0 properties defined by another context", func(t *testing.T) {
+		const input = `{
+		  "@context": ["https://www.w3.org/2018/credentials/v1", "https://example.com/context"],
+		  "type": "VerifiableCredential",
+		  "issuer": "did:example:issuer",
+		  "issuanceDate": "2024-01-01T00:00:00Z",
+		  "name": 123,
+		  "description": {"nested": {"a": 1}},
+		  "validFrom": "yesterday",
+		  "credentialSubject": {"id": "did:example:subject"}
+		}`
+		credential, err := ParseVerifiableCredential(input)
+		require.NoError(t, err)
+
+		assert.Nil(t, credential.Name)
+		assert.Nil(t, credential.Description)
+		assert.Nil(t, credential.ValidFrom)
+		assert.Equal(t, map[string]interface{}{
+			"name":        float64(123),
+			"description": map[string]interface{}{"nested": map[string]interface{}{"a": float64(1)}},
+			"validFrom":   "yesterday",
+		}, credential.AdditionalProperties)
+		// round-trips without loss
+		credential.raw = ""
+		data, err := json.Marshal(credential)
+		require.NoError(t, err)
+		assert.JSONEq(t, input, string(data))
+	})
+	t.Run("JWT VCDM 2.0 maps nbf and exp to validFrom and validUntil", func(t *testing.T) {
+		token := jwt.New()
+		notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiration := notBefore.AddDate(1, 0, 0)
+		require.NoError(t, token.Set("vc", map[string]interface{}{"@context": []string{VCContextV2}}))
+		require.NoError(t, token.Set(jwt.NotBeforeKey, notBefore))
+		require.NoError(t, token.Set(jwt.ExpirationKey, expiration))
+		keyPair, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		tokenBytes, err := jwt.Sign(token, jwt.WithKey(jwa.ES256(), keyPair))
+		require.NoError(t, err)
+
+		credential, err := ParseVerifiableCredential(string(tokenBytes))
+
+		require.NoError(t, err)
+		assert.Equal(t, DataModelV2, credential.Version())
+		assert.Equal(t, notBefore, credential.ValidFrom.UTC())
+		assert.Equal(t, expiration, credential.ValidUntil.UTC())
+		assert.Empty(t, credential.IssuanceDate)
+		assert.Nil(t, credential.ExpirationDate)
+	})
+}
+
+func TestVerifiableCredential_IssuerObject(t *testing.T) {
+	const input = `{
+	  "@context": ["https://www.w3.org/2018/credentials/v1"],
+	  "type": "VerifiableCredential",
+	  "issuer": {"id": "did:example:issuer", "name": "ACME"},
+	  "issuanceDate": "2024-01-01T00:00:00Z",
+	  "credentialSubject": {"id": "did:example:subject"}
+	}`
+	t.Run("JSON-LD", func(t *testing.T) {
+		credential, err := ParseVerifiableCredential(input)
+		require.NoError(t, err)
+
+		assert.Equal(t, "did:example:issuer", credential.Issuer.ID().String())
+		assert.Equal(t, map[string]interface{}{"name": "ACME"}, credential.Issuer.Properties())
+		issuerDID, err := credential.Issuer.DID()
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:issuer", issuerDID.String())
+		// issuer object is retained when marshalling
+		credential.raw = ""
+		data, err := json.Marshal(credential)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"issuer":{"id":"did:example:issuer","name":"ACME"}`)
+	})
+	t.Run("JWT", func(t *testing.T) {
+		template, err := ParseVerifiableCredential(input)
+		require.NoError(t, err)
+		keyPair, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		signer := func(_ context.Context, claims map[string]interface{}, _ map[string]interface{}) (string, error) {
+			token := jwt.New()
+			for key, value := range claims {
+				if err := token.Set(key, value); err != nil {
+					return "", err
+				}
+			}
+			data, err := jwt.Sign(token, jwt.WithKey(jwa.ES256(), keyPair))
+			return string(data), err
+		}
+
+		credential, err := CreateJWTVerifiableCredential(context.Background(), *template, signer)
+
+		require.NoError(t, err)
+		iss, _ := credential.JWT().Issuer()
+		assert.Equal(t, "did:example:issuer", iss)
+		assert.Equal(t, template.Issuer, credential.Issuer)
+	})
+}
+
+func TestVerifiableCredential_ExtensionProperties(t *testing.T) {
+	const input = `{
+	  "@context": ["https://www.w3.org/2018/credentials/v1", "https://example.com/context"],
+	  "type": "VerifiableCredential",
+	  "issuer": "did:example:issuer",
+	  "issuanceDate": "2024-01-01T00:00:00Z",
+	  "credentialSubject": {"id": "did:example:subject"},
+	  "credentialSchema": {"id": "https://example.com/schema.json", "type": "JsonSchema", "digestSRI": "sha384-abc"},
+	  "refreshService": {"id": "https://example.com/refresh", "type": "ManualRefreshService2018", "url": "https://example.com"},
+	  "termsOfUse": {"type": "IssuerPolicy", "prohibition": [{"action": ["Archival"]}]},
+	  "evidence": {"id": "https://example.com/evidence/1", "type": ["DocumentVerification"], "verifier": "did:example:verifier"},
+	  "custom": {"name": "test"}
+	}`
+	t.Run("JSON-LD", func(t *testing.T) {
+		credential, err := ParseVerifiableCredential(input)
+		require.NoError(t, err)
+
+		require.Len(t, credential.RefreshService, 1)
+		assert.Equal(t, []string{"ManualRefreshService2018"}, credential.RefreshService[0].Type)
+		assert.Equal(t, "https://example.com/refresh", credential.RefreshService[0].ID.String())
+		require.Len(t, credential.TermsOfUse, 1)
+		assert.Equal(t, []string{"IssuerPolicy"}, credential.TermsOfUse[0].Type)
+		assert.Nil(t, credential.TermsOfUse[0].ID)
+		require.Len(t, credential.Evidence, 1)
+		assert.Equal(t, []string{"DocumentVerification"}, credential.Evidence[0].Type)
+		assert.JSONEq(t, `{"id": "https://example.com/evidence/1", "type": ["DocumentVerification"], "verifier": "did:example:verifier"}`, string(credential.Evidence[0].Raw()))
+		assert.Equal(t, map[string]interface{}{"custom": map[string]interface{}{"name": "test"}}, credential.AdditionalProperties)
+		// round-trips without loss
+		credential.raw = ""
+		data, err := json.Marshal(credential)
+		require.NoError(t, err)
+		var expected, actual map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(input), &expected))
+		require.NoError(t, json.Unmarshal(data, &actual))
+		// evidence type is marshalled as string, since it has a single value
+		expected["evidence"].(map[string]interface{})["type"] = "DocumentVerification"
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("malformed optional properties are retained as additional properties", func(t *testing.T) {
+		const input = `{
+		  "@context": ["https://www.w3.org/2018/credentials/v1", "https://example.com/context"],
+		  "type": "VerifiableCredential",
+		  "issuer": "did:example:issuer",
+		  "issuanceDate": "2024-01-01T00:00:00Z",
+		  "credentialSubject": {"id": "did:example:subject"},
+		  "refreshService": "https://example.com/refresh",
+		  "termsOfUse": {"type": ["A", "B"]}
+		}`
+		credential, err := ParseVerifiableCredential(input)
+		require.NoError(t, err)
+
+		assert.Empty(t, credential.RefreshService)
+		assert.Equal(t, map[string]interface{}{"refreshService": "https://example.com/refresh"}, credential.AdditionalProperties)
+		require.Len(t, credential.TermsOfUse, 1)
+		assert.Equal(t, []string{"A", "B"}, credential.TermsOfUse[0].Type)
+		// round-trips without loss
+		credential.raw = ""
+		data, err := json.Marshal(credential)
+		require.NoError(t, err)
+		assert.JSONEq(t, input, string(data))
+	})
+	t.Run("additional properties can't override designated fields", func(t *testing.T) {
+		credential := VerifiableCredential{
+			Context:              []ssi.URI{VCContextV1URI()},
+			Type:                 []ssi.URI{VerifiableCredentialTypeV1URI()},
+			AdditionalProperties: map[string]interface{}{"type": "Other", "custom": true},
+		}
+		data, err := json.Marshal(credential)
+		require.NoError(t, err)
+		var actual map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &actual))
+		assert.Equal(t, "VerifiableCredential", actual["type"])
+		assert.Equal(t, true, actual["custom"])
+	})
+	t.Run("JWT", func(t *testing.T) {
+		template, err := ParseVerifiableCredential(input)
+		require.NoError(t, err)
+		keyPair, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		signer := func(_ context.Context, claims map[string]interface{}, _ map[string]interface{}) (string, error) {
+			token := jwt.New()
+			for key, value := range claims {
+				if err := token.Set(key, value); err != nil {
+					return "", err
+				}
+			}
+			data, err := jwt.Sign(token, jwt.WithKey(jwa.ES256(), keyPair))
+			return string(data), err
+		}
+
+		credential, err := CreateJWTVerifiableCredential(context.Background(), *template, signer)
+
+		require.NoError(t, err)
+		assert.Equal(t, template.CredentialSchema[0].Raw(), credential.CredentialSchema[0].Raw())
+		assert.Equal(t, template.RefreshService[0].Raw(), credential.RefreshService[0].Raw())
+		assert.Equal(t, template.TermsOfUse[0].Raw(), credential.TermsOfUse[0].Raw())
+		assert.JSONEq(t, `{"id": "https://example.com/evidence/1", "type": "DocumentVerification", "verifier": "did:example:verifier"}`, string(credential.Evidence[0].Raw()))
+		assert.Equal(t, template.AdditionalProperties, credential.AdditionalProperties)
+	})
+}
+
+func TestVerifiableCredential_Version(t *testing.T) {
+	assert.Equal(t, DataModelV1, VerifiableCredential{Context: []ssi.URI{VCContextV1URI()}}.Version())
+	assert.Equal(t, DataModelV2, VerifiableCredential{Context: []ssi.URI{VCContextV2URI()}}.Version())
+	assert.Equal(t, UnknownDataModelVersion, VerifiableCredential{Context: []ssi.URI{ssi.MustParseURI("https://example.com")}}.Version())
+	assert.Equal(t, UnknownDataModelVersion, VerifiableCredential{}.Version())
+}
+
+func TestVerifiableCredential_Clone(t *testing.T) {
+	t.Run("JSON-LD", func(t *testing.T) {
+		original, err := ParseVerifiableCredential(`{
+		  "id":"did:example:123#vc-1",
+		  "type":["VerifiableCredential", "custom"],
+		  "issuanceDate": "2023-01-01T00:00:00Z",
+		  "expirationDate": "2024-01-01T00:00:00Z",
+		  "credentialSubject": {"name": "test", "address": {"city": "Amsterdam"}},
+		  "credentialStatus": {"id": "example.com", "type": "Custom"},
+		  "custom": {"name": "test"}
+		}`)
+		require.NoError(t, err)
+
+		actual := original.Clone()
+
+		assert.Equal(t, original, actual)
+		actual.AdditionalProperties["custom"].(map[string]interface{})["name"] = "changed"
+		assert.Equal(t, "test", original.AdditionalProperties["custom"].(map[string]interface{})["name"])
+		actual.CredentialSubject[0]["address"].(map[string]interface{})["city"] = "Utrecht"
+		*actual.ExpirationDate = time.Now()
+		actual.Type[1] = ssi.MustParseURI("other")
+		assert.Equal(t, "Amsterdam", original.CredentialSubject[0]["address"].(map[string]interface{})["city"])
+		assert.Equal(t, 2024, original.ExpirationDate.Year())
+		assert.Equal(t, "custom", original.Type[1].String())
+	})
+	t.Run("JWT", func(t *testing.T) {
+		original, err := ParseVerifiableCredential(jwtCredential)
+		require.NoError(t, err)
+
+		actual := original.Clone()
+
+		assert.Equal(t, original.Raw(), actual.Raw())
+		assert.Equal(t, JWTCredentialProofFormat, actual.Format())
+		require.NotNil(t, actual.token)
+		assert.NotSame(t, original.token, actual.token)
+		require.NoError(t, actual.token.Set("nonce", "changed"))
+		var nonce string
+		require.NoError(t, original.token.Get("nonce", &nonce))
+		assert.NotEqual(t, "changed", nonce)
+	})
 }
 
 func TestVerifiableCredential_UnmarshalCredentialSubject(t *testing.T) {
@@ -205,6 +501,17 @@ func TestCredentialStatus_UnmarshalJSON(t *testing.T) {
 		assert.Equal(t, "urn:uuid:7facf41c-1dc5-486b-87e6-587d015e76d7?bit-index=10", actual.ID.String())
 		assert.Greater(t, len(actual.raw), 1)
 	})
+	t.Run("regression: doesn't recurse into UnmarshalJSON", func(t *testing.T) {
+		var actual []CredentialStatus
+
+		err := json.Unmarshal([]byte(`[{"id": "https://example.com/status/1#10", "type": "StatusList2021Entry", "statusListIndex": "10"}]`), &actual)
+
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, "https://example.com/status/1#10", actual[0].ID.String())
+		assert.Equal(t, "StatusList2021Entry", actual[0].Type)
+		assert.JSONEq(t, `{"id": "https://example.com/status/1#10", "type": "StatusList2021Entry", "statusListIndex": "10"}`, string(actual[0].Raw()))
+	})
 }
 
 func TestCredentialStatus_Raw(t *testing.T) {
@@ -401,7 +708,7 @@ func TestVerifiableCredential_SubjectDID(t *testing.T) {
 
 		_, err := input.SubjectDID()
 
-		assert.EqualError(t, err, "unable to get subject DID from VC: invalid DID")
+		assert.EqualError(t, err, "unable to get subject DID from VC: invalid DID: syntax error at position 0: expected DID (starting with 'did:') or relative DID URL (starting with '/', '?' or '#')")
 	})
 }
 
@@ -431,7 +738,7 @@ func TestCreateJWTVerifiableCredential(t *testing.T) {
 			ID:   ssi.MustParseURI("did:example:something"),
 			Type: "test",
 		}},
-		Issuer: issuerDID.URI(),
+		Issuer: NewIdentifier(issuerDID.URI()),
 	}
 	ctx := context.Background()
 	t.Run("all properties", func(t *testing.T) {
@@ -484,6 +791,78 @@ func TestCreateJWTVerifiableCredential(t *testing.T) {
 		assert.False(t, nbf.IsZero())
 		assert.Equal(t, nbf, claims[jwt.IssuedAtKey])
 	})
+	t.Run("VCDM 2.0 uses validFrom and validUntil", func(t *testing.T) {
+		v2Template := template
+		v2Template.Context = []ssi.URI{VCContextV2URI()}
+		v2Template.IssuanceDate = time.Time{}
+		v2Template.ExpirationDate = nil
+		v2Template.ValidFrom = &issuanceDate
+		v2Template.ValidUntil = &expirationDate
+		v2Template.Name = LanguageValues{{Value: "Example", Language: "en"}}
+		v2Template.RelatedResource = []RelatedResource{{ID: ssi.MustParseURI("https://example.com/logo.png"), DigestSRI: "sha384-abc"}}
+		var claims map[string]interface{}
+		_, err := CreateJWTVerifiableCredential(ctx, v2Template, func(_ context.Context, c map[string]interface{}, _ map[string]interface{}) (string, error) {
+			claims = c
+			return jwtCredential, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, issuanceDate, claims[jwt.NotBeforeKey])
+		assert.Equal(t, issuanceDate, claims[jwt.IssuedAtKey])
+		assert.Equal(t, expirationDate, claims[jwt.ExpirationKey])
+		vcMap := claims["vc"].(map[string]interface{})
+		assert.Equal(t, v2Template.Name, vcMap["name"])
+		assert.Equal(t, v2Template.RelatedResource, vcMap["relatedResource"])
+		assert.NotContains(t, vcMap, "description")
+	})
+	t.Run("VCDM 2.0 without validFrom doesn't set nbf", func(t *testing.T) {
+		v2Template := template
+		v2Template.Context = []ssi.URI{VCContextV2URI()}
+		v2Template.IssuanceDate = time.Time{}
+		v2Template.ExpirationDate = nil
+		keyPair, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		var claims map[string]interface{}
+		credential, err := CreateJWTVerifiableCredential(ctx, v2Template, func(_ context.Context, c map[string]interface{}, _ map[string]interface{}) (string, error) {
+			claims = c
+			token := jwt.New()
+			for key, value := range c {
+				if err := token.Set(key, value); err != nil {
+					return "", err
+				}
+			}
+			data, err := jwt.Sign(token, jwt.WithKey(jwa.ES256(), keyPair))
+			return string(data), err
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, claims, jwt.NotBeforeKey)
+		assert.NotContains(t, claims, jwt.ExpirationKey)
+		iat, ok := claims[jwt.IssuedAtKey].(time.Time)
+		assert.True(t, ok)
+		assert.False(t, iat.IsZero())
+		assert.Nil(t, credential.ValidFrom)
+		assert.Nil(t, credential.ValidUntil)
+	})
+	t.Run("VCDM 2.0 with VCDM 1.1 dates returns error", func(t *testing.T) {
+		for name, modify := range map[string]func(*VerifiableCredential){
+			"issuanceDate":   func(credential *VerifiableCredential) { credential.IssuanceDate = issuanceDate },
+			"expirationDate": func(credential *VerifiableCredential) { credential.ExpirationDate = &expirationDate },
+		} {
+			t.Run(name, func(t *testing.T) {
+				v2Template := template
+				v2Template.Context = []ssi.URI{VCContextV2URI()}
+				v2Template.IssuanceDate = time.Time{}
+				v2Template.ExpirationDate = nil
+				v2Template.ValidFrom = &issuanceDate
+				modify(&v2Template)
+
+				_, err := CreateJWTVerifiableCredential(ctx, v2Template, func(_ context.Context, _ map[string]interface{}, _ map[string]interface{}) (string, error) {
+					t.Fatal("signer should not be called")
+					return "", nil
+				})
+
+				assert.EqualError(t, err, "VCDM 2.0 credentials must use ValidFrom and ValidUntil instead of IssuanceDate and ExpirationDate")
+			})
+		}
+	})
 	t.Run("WithCredentialSubjectAsObject", func(t *testing.T) {
 		t.Run("single credentialSubject", func(t *testing.T) {
 			var claims map[string]interface{}
@@ -525,4 +904,17 @@ func TestVerifiableCredential_ValidAt(t *testing.T) {
 	assert.True(t, VerifiableCredential{IssuanceDate: low, ExpirationDate: &high}.ValidAt(mid, skew))
 	assert.True(t, VerifiableCredential{IssuanceDate: low, ExpirationDate: &mid}.ValidAt(high, skew))
 	assert.True(t, VerifiableCredential{IssuanceDate: mid, ExpirationDate: &high}.ValidAt(low, skew))
+	t.Run("VCDM 2.0", func(t *testing.T) {
+		v2 := []ssi.URI{VCContextV2URI()}
+		assert.True(t, VerifiableCredential{Context: v2}.ValidAt(time.Now(), 0))
+		assert.True(t, VerifiableCredential{Context: v2, ValidFrom: &low, ValidUntil: &high}.ValidAt(mid, 0))
+		assert.False(t, VerifiableCredential{Context: v2, ValidFrom: &low, ValidUntil: &mid}.ValidAt(high, 0))
+		assert.False(t, VerifiableCredential{Context: v2, ValidFrom: &mid, ValidUntil: &high}.ValidAt(low, 0))
+		assert.True(t, VerifiableCredential{Context: v2, ValidFrom: &mid, ValidUntil: &high}.ValidAt(low, skew))
+		// issuanceDate and expirationDate are not defined in VCDM 2.0
+		assert.True(t, VerifiableCredential{Context: v2, IssuanceDate: mid, ExpirationDate: &mid}.ValidAt(high, 0))
+	})
+	t.Run("VCDM 1.1 ignores validFrom and validUntil", func(t *testing.T) {
+		assert.True(t, VerifiableCredential{Context: []ssi.URI{VCContextV1URI()}, ValidFrom: &high, ValidUntil: &low}.ValidAt(mid, 0))
+	})
 }
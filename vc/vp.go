@@ -12,6 +12,7 @@ import (
 	"github.com/lestrrat-go/jwx/v3/jws"
 	"github.com/lestrrat-go/jwx/v3/jwt"
 	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/internal/clone"
 	"github.com/nuts-foundation/go-did/internal/marshal"
 )
 
@@ -39,8 +40,8 @@ type VerifiablePresentation struct {
 	ID *ssi.URI `json:"id,omitempty"`
 	// Type holds multiple types for a presentation. A presentation must always have the 'VerifiablePresentation' type.
 	Type []ssi.URI `json:"type"`
-	// Holder refers to the party that generated the presentation. It is optional
-	Holder *ssi.URI `json:"holder,omitempty"`
+	// Holder refers to the party that generated the presentation. It is either a URI or an object with an `id`. It is optional
+	Holder *IdentifierOrObject `json:"holder,omitempty"`
 	// VerifiableCredential may hold credentials that are proven with this presentation.
 	VerifiableCredential []VerifiableCredential `json:"verifiableCredential,omitempty"`
 	// Proof contains the cryptographic proof(s). It must be extracted using the Proofs method or UnmarshalProofValue method for non-generic proof fields.
@@ -104,8 +105,9 @@ func parseJTWPresentation(raw string) (*VerifiablePresentation, error) {
 	// parse iss
 	if iss, err := parseURIClaim(token, jwt.IssuerKey); err != nil {
 		return nil, err
-	} else if iss != nil {
-		result.Holder = iss
+	} else if iss != nil && (result.Holder == nil || result.Holder.ID().String() != iss.String()) {
+		holder := NewIdentifier(*iss)
+		result.Holder = &holder
 	}
 	// the other claims don't have a designated field in VerifiablePresentation and can be accessed through JWT()
 	result.format = JWTPresentationProofFormat
@@ -125,6 +127,11 @@ func parseURIClaim(token jwt.Token, claim string) (*ssi.URI, error) {
 	return ssi.ParseURI(str)
 }
 
+// Version returns the version of the Verifiable Credentials Data Model the presentation conforms to, as indicated by its first `@context`.
+func (vp VerifiablePresentation) Version() DataModelVersion {
+	return dataModelVersion(vp.Context)
+}
+
 // Format returns the format of the presentation (e.g. jwt_vp or ldp_vp).
 func (vp VerifiablePresentation) Format() string {
 	return vp.format
@@ -139,6 +146,17 @@ func (vp VerifiablePresentation) JWT() jwt.Token {
 	return token
 }
 
+// Clone returns a deep copy of the presentation, including the contained credentials and
+// the JWT token if the presentation was parsed from a JWT.
+func (vp VerifiablePresentation) Clone() *VerifiablePresentation {
+	result := clone.Of(vp)
+	for i, credential := range vp.VerifiableCredential {
+		result.VerifiableCredential[i] = *credential.Clone()
+	}
+	result.token = vp.JWT()
+	return &result
+}
+
 // Raw returns the source of the presentation as it was parsed.
 func (vp VerifiablePresentation) Raw() string {
 	return vp.raw
@@ -251,7 +269,7 @@ type PresentationOptions struct {
 
 // CreateJWTVerifiablePresentation creates a VC Data Model v1.1 JWT Verifiable Presentation from the given credentials and options.
 // For signing the actual JWT it calls the given signer, which must return the created JWT in string format.
-// Note: the signer is responsible for adding the right key claims (e.g. `kid`).
+// Note: the signer is responsible for adding the right key claims (e.g. `kid`), which signers created with NewJWTSigner do.
 // The implementation follows the W3C VC Data Model v1.1 spec: https://www.w3.org/TR/vc-data-model-1.1/#json-web-token
 func CreateJWTVerifiablePresentation(ctx context.Context, presenter ssi.URI, credentials []VerifiableCredential, options PresentationOptions, signer JWTSigner) (*VerifiablePresentation, error) {
 	headers := map[string]interface{}{
@@ -210,6 +210,16 @@ func TestParseVerifiablePresentation(t *testing.T) {
 		assert.Nil(t, vp.JWT())
 		assert.Equal(t, raw, vp.Raw())
 	})
+	t.Run("JSON-LD with holder object", func(t *testing.T) {
+		vp, err := ParseVerifiablePresentation(`{
+		  "@context":["https://www.w3.org/2018/credentials/v1"],
+		  "type":"VerifiablePresentation",
+		  "holder":{"id":"did:example:holder","name":"Jane"}
+		}`)
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:holder", vp.Holder.ID().String())
+		assert.Equal(t, map[string]interface{}{"name": "Jane"}, vp.Holder.Properties())
+	})
 	t.Run("JWT", func(t *testing.T) {
 		vp, err := ParseVerifiablePresentation(jwtPresentation)
 		require.NoError(t, err)
@@ -242,6 +252,37 @@ func TestParseVerifiablePresentation(t *testing.T) {
 	})
 }
 
+func TestVerifiablePresentation_Clone(t *testing.T) {
+	t.Run("JSON-LD", func(t *testing.T) {
+		original, err := ParseVerifiablePresentation(`{
+		  "id":"did:example:123#vp-1",
+		  "@context":["https://www.w3.org/2018/credentials/v1"],
+		  "verifiableCredential": {"type": "VerifiableCredential", "credentialSubject": {"name": "test"}}
+		}`)
+		require.NoError(t, err)
+
+		actual := original.Clone()
+
+		assert.Equal(t, original, actual)
+		actual.VerifiableCredential[0].CredentialSubject[0]["name"] = "changed"
+		actual.ID.Fragment = "changed"
+		assert.Equal(t, "test", original.VerifiableCredential[0].CredentialSubject[0]["name"])
+		assert.Equal(t, "vp-1", original.ID.Fragment)
+	})
+	t.Run("JWT", func(t *testing.T) {
+		original, err := ParseVerifiablePresentation(jwtPresentation)
+		require.NoError(t, err)
+
+		actual := original.Clone()
+
+		assert.Equal(t, original.Raw(), actual.Raw())
+		require.NotNil(t, actual.token)
+		assert.NotSame(t, original.token, actual.token)
+		require.Len(t, actual.VerifiableCredential, 1)
+		assert.NotSame(t, original.VerifiableCredential[0].token, actual.VerifiableCredential[0].token)
+	})
+}
+
 func TestCreateJWTVerifiablePresentation(t *testing.T) {
 	keyPair, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	signJWT := func(_ context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
@@ -327,7 +368,7 @@ func TestCreateJWTVerifiablePresentation(t *testing.T) {
 		assert.NotContains(t, tokenVP, "holder")
 
 		// Verify VP structure
-		assert.Equal(t, &holderDID, vp.Holder)
+		assert.Equal(t, holderDID, vp.Holder.ID())
 		assert.Len(t, vp.VerifiableCredential, 1)
 		assert.Len(t, vp.Context, 2)
 		assert.Equal(t, VCContextV1URI(), vp.Context[0])